@@ -11,12 +11,26 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
 
 	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/events"
 	"github.com/GunarsK-templates/template-api/internal/handlers"
+	"github.com/GunarsK-templates/template-api/internal/health"
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/jobs"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/middleware/metrics"
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+	"github.com/GunarsK-templates/template-api/internal/middleware/tracing"
 	"github.com/GunarsK-templates/template-api/internal/repository"
 	"github.com/GunarsK-templates/template-api/internal/routes"
+	"github.com/GunarsK-templates/template-api/internal/stream"
+	"github.com/GunarsK-templates/template-api/internal/telemetry"
+	"github.com/GunarsK-templates/template-api/internal/webhooks"
 )
 
 // @title           Your Service API
@@ -37,22 +51,27 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+// @description Some operations additionally require roles or scopes, listed in their descriptions.
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key for machine clients, issued via /api-keys.
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "user" {
+		os.Exit(runUser(os.Args[2:]))
+	}
+
 	// Load configuration
 	cfg := config.Load()
 
 	// Setup logger
-	logLevel := slog.LevelInfo
-	if cfg.Service.Environment == "development" {
-		logLevel = slog.LevelDebug
-	}
-
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level:     logLevel,
-		AddSource: cfg.Service.Environment == "development",
-	}))
-	slog.SetDefault(logger)
+	setupLogger(cfg)
 
 	slog.Info("Starting service",
 		"service", cfg.Service.Name,
@@ -60,6 +79,17 @@ func main() {
 		"port", cfg.Service.Port,
 	)
 
+	if !cfg.HasJWT() {
+		slog.Warn("JWT_SECRET not set - item write routes are unauthenticated")
+	}
+
+	// Tracing; buffered spans are flushed on shutdown
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.Tracing, cfg.Service.Name, cfg.Service.Environment)
+	if err != nil {
+		slog.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
 	// Connect to database
 	db, err := repository.ConnectDB(cfg)
 	if err != nil {
@@ -67,11 +97,92 @@ func main() {
 		os.Exit(1)
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		slog.Error("Failed to get database handle", "error", err)
+		os.Exit(1)
+	}
+	migrator, err := newMigrator(sqlDB)
+	if err != nil {
+		slog.Error("Failed to load migrations", "error", err)
+		os.Exit(1)
+	}
+
+	// Pool statistics (go_sql_*) and query durations for spotting pool starvation
+	prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, cfg.Database.Name))
+	if err := db.Use(repository.NewQueryMetrics(prometheus.DefaultRegisterer, cfg.Metrics.QueryBuckets)); err != nil {
+		slog.Error("Failed to register query metrics", "error", err)
+		os.Exit(1)
+	}
+	if err := db.Use(repository.NewQueryTracing(otel.GetTracerProvider())); err != nil {
+		slog.Error("Failed to register query tracing", "error", err)
+		os.Exit(1)
+	}
+
+	// Apply pending migrations (opt-in; otherwise run "migrate up" before deploying)
+	if cfg.Database.AutoMigrate {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			slog.Error("Failed to apply migrations", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize repository
 	repo := repository.New(db)
 
+	// Item events for SSE clients, kept for resuming
+	broker := stream.NewBroker(cfg.Stream.BufferSize)
+
+	// Health probes check that the database answers and, unless the schema is
+	// managed elsewhere, that it is current
+	probes := health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	probes.Register("database", health.Ping(sqlDB), health.Ready|health.Startup)
+	if cfg.Health.CheckMigrations {
+		probes.Register("migrations", health.Migrations(migrator), health.Ready|health.Startup)
+	}
+
 	// Initialize handlers
-	handler := handlers.New(repo)
+	handler := handlers.New(repo, cfg, broker, probes)
+
+	// Background work (e.g. OIDC key refresh) stops when the server shuts down
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+
+	// Initialize token verifier
+	var verifier *auth.Verifier
+	if cfg.HasJWT() {
+		verifier, err = auth.NewVerifier(appCtx, cfg.JWT)
+		if err != nil {
+			slog.Error("Failed to initialize token verifier", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Permanently delete items that outlived the trash retention
+	if cfg.Service.TrashRetention > 0 {
+		go jobs.RunTrashPurger(appCtx, repo, cfg.Service.TrashRetention, cfg.Service.TrashPurgeInterval)
+	}
+
+	// Publish item events from the outbox, also queuing them for webhook subscriptions
+	var publisher events.Publisher = events.LogPublisher{}
+	if cfg.Outbox.Publisher == config.OutboxPublisherWebhook {
+		publisher = events.NewHTTPPublisher(cfg.Outbox.WebhookURL, cfg.Outbox.WebhookTimeout)
+	}
+	go jobs.RunOutboxRelay(appCtx, repo, events.MultiPublisher{publisher, webhooks.NewFanout(repo)}, jobs.OutboxOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	})
+
+	// Receive item changes from all replicas for SSE clients
+	go stream.Listen(appCtx, cfg.Database.DSN(), broker)
+
+	// Send queued webhook deliveries, to public addresses only
+	go jobs.RunWebhookDispatcher(appCtx, repo, webhooks.NewClient(cfg.Webhook.Timeout), jobs.WebhookOptions{
+		PollInterval: cfg.Webhook.PollInterval,
+		MaxAttempts:  cfg.Webhook.MaxAttempts,
+		MaxBackoff:   cfg.Webhook.MaxBackoff,
+	})
 
 	// Setup Gin router
 	if cfg.Service.Environment == "production" {
@@ -80,10 +191,18 @@ func main() {
 
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(requestid.Middleware())
+	router.Use(i18n.Middleware())
+	router.Use(tracing.Middleware(otel.GetTracerProvider(), cfg.Tracing.ExcludePaths))
 	router.Use(requestLogger())
+	router.Use(metrics.Middleware(prometheus.DefaultRegisterer, metrics.Options{
+		DurationBuckets: cfg.Metrics.DurationBuckets,
+		SizeBuckets:     cfg.Metrics.SizeBuckets,
+		ExcludePaths:    cfg.Metrics.ExcludePaths,
+	}))
 
 	// Setup routes
-	routes.Setup(router, handler, cfg)
+	routes.Setup(router, handler, cfg, verifier, repo)
 
 	// Metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -97,6 +216,9 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// End event streams on shutdown; Shutdown does not interrupt active requests
+	srv.RegisterOnShutdown(broker.Reset)
+
 	// Start server in goroutine
 	go func() {
 		slog.Info("Server listening", "addr", srv.Addr)
@@ -113,6 +235,11 @@ func main() {
 
 	slog.Info("Shutting down server...")
 
+	// Fail readiness first and keep serving while load balancers notice
+	probes.Shutdown()
+	time.Sleep(cfg.Health.ShutdownDelay)
+	stopApp()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -120,10 +247,28 @@ func main() {
 		slog.Error("Server forced to shutdown", "error", err)
 		os.Exit(1)
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
 
 	slog.Info("Server exited gracefully")
 }
 
+// setupLogger installs the default JSON logger for the configured environment
+func setupLogger(cfg *config.Config) {
+	logLevel := slog.LevelInfo
+	if cfg.Service.Environment == "development" {
+		logLevel = slog.LevelDebug
+	}
+
+	// Records logged with a request context carry its request_id, trace_id and span_id
+	logger := slog.New(telemetry.NewLogHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:     logLevel,
+		AddSource: cfg.Service.Environment == "development",
+	})))
+	slog.SetDefault(logger)
+}
+
 // requestLogger logs HTTP requests
 func requestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -132,7 +277,7 @@ func requestLogger() gin.HandlerFunc {
 
 		c.Next()
 
-		slog.Info("Request",
+		slog.InfoContext(c.Request.Context(), "Request",
 			"method", c.Request.Method,
 			"path", path,
 			"status", c.Writer.Status(),
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/migrate"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// migrateUsage documents the migrate subcommand
+const migrateUsage = `Usage: api migrate <command> [flags]
+
+Commands:
+  up                        Apply all pending migrations
+  down [-steps N]           Roll back the last N applied migrations (default 1)
+  status                    List migrations and when they were applied
+  create [-dir DIR] NAME    Create an empty up/down migration pair in DIR
+                            (default ` + migrate.Dir + `)
+`
+
+// runMigrate runs the migrate subcommand and returns the process exit code
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	command, args := args[0], args[1:]
+
+	// create only touches the source tree, so it needs no configuration
+	if command == "create" {
+		return createMigration(args)
+	}
+
+	steps := 1
+	flags := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	if command == "down" {
+		flags.IntVar(&steps, "steps", 1, "number of migrations to roll back")
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	switch command {
+	case "up", "down", "status":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n\n%s", command, migrateUsage)
+		return 2
+	}
+	if steps < 1 {
+		fmt.Fprintln(os.Stderr, "-steps must be at least 1")
+		return 2
+	}
+
+	cfg := config.Load()
+	setupLogger(cfg)
+
+	db, err := repository.ConnectDB(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to connect to database:", err)
+		return 1
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to get database handle:", err)
+		return 1
+	}
+	defer sqlDB.Close() //nolint:errcheck // process exits right after
+
+	migrator, err := newMigrator(sqlDB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	case "down":
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", rolledBack)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if errors.Is(err, migrate.ErrNotInitialized) {
+			fmt.Println(`Not initialized: no migrations have been applied (run "migrate up")`)
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		printMigrationStatus(os.Stdout, statuses)
+	}
+	return 0
+}
+
+// createMigration implements "migrate create"
+func createMigration(args []string) int {
+	flags := flag.NewFlagSet("migrate create", flag.ContinueOnError)
+	dir := flags.String("dir", migrate.Dir, "migrations directory")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	upPath, downPath, err := migrate.Create(*dir, flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println("Created", upPath)
+	fmt.Println("Created", downPath)
+	return 0
+}
+
+// newMigrator creates a Migrator for the embedded migrations
+func newMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	migrations, err := migrate.Embedded()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, migrations), nil
+}
+
+// printMigrationStatus writes a status table
+func printMigrationStatus(w io.Writer, statuses []migrate.Status) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	tw.Flush() //nolint:errcheck // best-effort terminal output
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// userUsage documents the user subcommand
+const userUsage = `Usage: api user <command> [flags]
+
+Commands:
+  create -username NAME [-role ROLE]...
+                            Create a user who can log in at /auth/token. The
+                            password is read from the first line of stdin.
+`
+
+// minPasswordLength is the shortest password user create accepts
+const minPasswordLength = 8
+
+// userStore stores new users, see repository.Repository
+type userStore interface {
+	CreateUser(ctx context.Context, user *models.User) error
+}
+
+// runUser runs the user subcommand and returns the process exit code
+func runUser(args []string) int {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+
+	var roles []string
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	username := flags.String("username", "", "login name of the user")
+	flags.Func("role", "role of the user, e.g. "+auth.RoleAdmin+" (repeatable)", func(role string) error {
+		roles = append(roles, role)
+		return nil
+	})
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprint(os.Stderr, userUsage)
+		return 2
+	}
+
+	cfg := config.Load()
+	setupLogger(cfg)
+
+	db, err := repository.ConnectDB(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to connect to database:", err)
+		return 1
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to get database handle:", err)
+		return 1
+	}
+	defer sqlDB.Close() //nolint:errcheck // process exits right after
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	user, err := createUser(ctx, repository.New(db), os.Stdin, *username, roles)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("Created user %q (id %d, roles %q)\n", user.Username, user.ID, user.Roles)
+	return 0
+}
+
+// createUser validates the user, reads the password from the first line of
+// password and stores the user with its bcrypt hash
+func createUser(ctx context.Context, store userStore, password io.Reader, username string, roles []string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, errors.New("-username is required")
+	}
+	for i, role := range roles {
+		roles[i] = strings.TrimSpace(role)
+		if roles[i] == "" || strings.Contains(roles[i], ",") {
+			return nil, fmt.Errorf("invalid role %q", role)
+		}
+	}
+
+	line, err := bufio.NewReader(password).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read password: %w", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) < minPasswordLength {
+		return nil, fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	hash, err := auth.HashPassword(line)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{Username: username, PasswordHash: hash, Roles: strings.Join(roles, ",")}
+	if err := store.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
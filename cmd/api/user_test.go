@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// userRecorder records created users and fails with err
+type userRecorder struct {
+	users []*models.User
+	err   error
+}
+
+func (r *userRecorder) CreateUser(_ context.Context, user *models.User) error {
+	if r.err != nil {
+		return r.err
+	}
+	user.ID = int64(len(r.users) + 1)
+	r.users = append(r.users, user)
+	return nil
+}
+
+// =============================================================================
+// User Create Tests
+// =============================================================================
+
+func TestCreateUser_StoresBcryptHashAndRoles(t *testing.T) {
+	store := &userRecorder{}
+
+	user, err := createUser(context.Background(), store, strings.NewReader("s3cret-password\n"), " alice ", []string{"admin", " editor"})
+	if err != nil {
+		t.Fatalf("createUser() error = %v", err)
+	}
+
+	if len(store.users) != 1 || user.ID != 1 || user.Username != "alice" || user.Roles != "admin,editor" {
+		t.Errorf("user = %+v, want alice with roles admin,editor stored", user)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("s3cret-password")); err != nil {
+		t.Errorf("password hash does not match the password read from stdin: %v", err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(user.PasswordHash)); cost != auth.PasswordCost {
+		t.Errorf("bcrypt cost = %d, want %d", cost, auth.PasswordCost)
+	}
+}
+
+func TestCreateUser_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		roles    []string
+		password string
+		storeErr error
+		wantErr  string
+	}{
+		{name: "missing username", username: " ", password: "s3cret-password\n", wantErr: "-username is required"},
+		{name: "empty role", username: "alice", roles: []string{""}, password: "s3cret-password\n", wantErr: "invalid role"},
+		{name: "role with comma", username: "alice", roles: []string{"admin,editor"}, password: "s3cret-password\n", wantErr: "invalid role"},
+		{name: "no password", username: "alice", wantErr: "at least 8 characters"},
+		{name: "short password", username: "alice", password: "short\n", wantErr: "at least 8 characters"},
+		{name: "store error", username: "alice", password: "s3cret-password", storeErr: errors.New("duplicate key"), wantErr: "duplicate key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &userRecorder{err: tt.storeErr}
+
+			_, err := createUser(context.Background(), store, strings.NewReader(tt.password), tt.username, tt.roles)
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("createUser() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if len(store.users) != 0 {
+				t.Errorf("users = %+v, want none stored", store.users)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package audit carries the actor of a change through the request context
+// and computes the field-level diffs recorded in audit events.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Audited actions
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// Actors recorded when no authenticated caller is known
+const (
+	AnonymousActor = "anonymous"     // Unauthenticated requests (JWT not configured)
+	SystemActor    = "system:purger" // Background trash purger
+)
+
+// Metadata describes who made a change and in which request
+type Metadata struct {
+	Actor     string
+	RequestID string
+}
+
+type contextKey struct{}
+
+// WithMetadata returns a context carrying m for audit events recorded with it
+func WithMetadata(ctx context.Context, m Metadata) context.Context {
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
+// FromContext returns the metadata stored by WithMetadata.
+// The actor defaults to AnonymousActor.
+func FromContext(ctx context.Context) Metadata {
+	m, _ := ctx.Value(contextKey{}).(Metadata)
+	if m.Actor == "" {
+		m.Actor = AnonymousActor
+	}
+	return m
+}
+
+// Change is the before and after value of one field; null when the field is absent
+type Change struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// Diff compares the JSON encodings of before and after, either of which may be nil,
+// and returns the changed top-level fields as {"field": {"before": ..., "after": ...}}
+func Diff(before, after any) (json.RawMessage, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, value := range beforeFields {
+		if !bytes.Equal(value, afterFields[key]) {
+			changes[key] = Change{Before: value, After: afterFields[key]}
+		}
+	}
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = Change{After: value}
+		}
+	}
+	return json.Marshal(changes)
+}
+
+// jsonFields encodes v and splits the resulting object into its fields
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if v == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	if string(data) == "null" {
+		return fields, nil
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("audit snapshot is not an object: %w", err)
+	}
+	return fields, nil
+}
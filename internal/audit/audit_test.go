@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"testing"
+)
+
+// =============================================================================
+// Diff Tests
+// =============================================================================
+
+type snapshot struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     int64  `json:"version"`
+}
+
+func TestDiff_TableDriven(t *testing.T) {
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   string
+	}{
+		{
+			name:  "create",
+			after: &snapshot{Name: "Widget", Version: 1},
+			want:  `{"name":{"before":null,"after":"Widget"},"version":{"before":null,"after":1}}`,
+		},
+		{
+			name:   "update changes only",
+			before: &snapshot{Name: "Widget", Description: "Blue", Version: 1},
+			after:  &snapshot{Name: "Widget", Description: "Red", Version: 2},
+			want:   `{"description":{"before":"Blue","after":"Red"},"version":{"before":1,"after":2}}`,
+		},
+		{
+			name:   "omitted field removed",
+			before: &snapshot{Name: "Widget", Description: "Blue", Version: 1},
+			after:  &snapshot{Name: "Widget", Version: 1},
+			want:   `{"description":{"before":"Blue","after":null}}`,
+		},
+		{
+			name:   "delete with typed nil",
+			before: &snapshot{Name: "Widget", Version: 3},
+			after:  (*snapshot)(nil),
+			want:   `{"name":{"before":"Widget","after":null},"version":{"before":3,"after":null}}`,
+		},
+		{
+			name:   "no changes",
+			before: &snapshot{Name: "Widget", Version: 1},
+			after:  &snapshot{Name: "Widget", Version: 1},
+			want:   `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Diff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_RejectsNonObjects(t *testing.T) {
+	if _, err := Diff([]int{1}, nil); err == nil {
+		t.Error("Diff() of an array should fail")
+	}
+}
+
+// =============================================================================
+// Context Tests
+// =============================================================================
+
+func TestFromContext_DefaultsToAnonymous(t *testing.T) {
+	if got := FromContext(context.Background()); got.Actor != AnonymousActor || got.RequestID != "" {
+		t.Errorf("FromContext() = %+v, want anonymous actor", got)
+	}
+
+	ctx := WithMetadata(context.Background(), Metadata{Actor: "42", RequestID: "req-1"})
+	if got := FromContext(ctx); got.Actor != "42" || got.RequestID != "req-1" {
+		t.Errorf("FromContext() = %+v, want stored metadata", got)
+	}
+}
@@ -4,6 +4,12 @@ package config
 type Config struct {
 	Service  ServiceConfig
 	Database DatabaseConfig
+	Outbox   OutboxConfig
+	Webhook  WebhookConfig
+	Stream   StreamConfig
+	Health   HealthConfig
+	Metrics  MetricsConfig
+	Tracing  TracingConfig
 	JWT      *JWTConfig // Optional - nil if JWT_SECRET not set
 }
 
@@ -12,6 +18,12 @@ func Load() *Config {
 	return &Config{
 		Service:  NewServiceConfig(),
 		Database: NewDatabaseConfig(),
+		Outbox:   NewOutboxConfig(),
+		Webhook:  NewWebhookConfig(),
+		Stream:   NewStreamConfig(),
+		Health:   NewHealthConfig(),
+		Metrics:  NewMetricsConfig(),
+		Tracing:  NewTracingConfig(),
 		JWT:      NewJWTConfig(),
 	}
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -16,6 +17,15 @@ type DatabaseConfig struct {
 	Password string `validate:"required"`
 	Name     string `validate:"required"`
 	SSLMode  string `validate:"required,oneof=disable require verify-ca verify-full"`
+
+	// AutoMigrate applies pending migrations at startup
+	AutoMigrate bool
+
+	// Connection pool
+	MaxOpenConns    int           `validate:"min=1"`
+	MaxIdleConns    int           `validate:"min=0,ltefield=MaxOpenConns"`
+	ConnMaxLifetime time.Duration `validate:"gte=0"` // 0 keeps connections forever
+	ConnMaxIdleTime time.Duration `validate:"gte=0"` // 0 keeps idle connections forever
 }
 
 // NewDatabaseConfig loads database configuration from environment variables
@@ -27,6 +37,13 @@ func NewDatabaseConfig() DatabaseConfig {
 		Password: utils.GetEnvRequired("DB_PASSWORD"),
 		Name:     utils.GetEnvRequired("DB_NAME"),
 		SSLMode:  utils.GetEnv("DB_SSL_MODE", "disable"),
+
+		AutoMigrate: utils.GetEnvBool("DB_AUTO_MIGRATE", false),
+
+		MaxOpenConns:    utils.GetEnvInt("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    utils.GetEnvInt("DB_MAX_IDLE_CONNS", 10),
+		ConnMaxLifetime: utils.GetEnvDuration("DB_CONN_MAX_LIFETIME", time.Hour),
+		ConnMaxIdleTime: utils.GetEnvDuration("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
 	}
 
 	validate := validator.New()
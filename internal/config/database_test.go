@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -18,7 +19,8 @@ func setEnvForTest(t *testing.T, key, value string) {
 // clearAllDatabaseEnvVars clears all database-related environment variables.
 func clearAllDatabaseEnvVars(t *testing.T) {
 	t.Helper()
-	vars := []string{"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSL_MODE"}
+	vars := []string{"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSL_MODE", "DB_AUTO_MIGRATE",
+		"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONN_MAX_IDLE_TIME"}
 	for _, v := range vars {
 		t.Setenv(v, "")
 		os.Unsetenv(v) //nolint:errcheck // test cleanup
@@ -78,6 +80,7 @@ func TestNewDatabaseConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	setEnvForTest(t, "DB_PASSWORD", "dbpass")
 	setEnvForTest(t, "DB_NAME", "mydb")
 	setEnvForTest(t, "DB_SSL_MODE", "require")
+	setEnvForTest(t, "DB_AUTO_MIGRATE", "true")
 
 	cfg := NewDatabaseConfig()
 
@@ -99,6 +102,9 @@ func TestNewDatabaseConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	if cfg.SSLMode != "require" {
 		t.Errorf("SSLMode = %q, want %q", cfg.SSLMode, "require")
 	}
+	if !cfg.AutoMigrate {
+		t.Error("AutoMigrate = false, want true")
+	}
 }
 
 func TestNewDatabaseConfig_UsesDefaultsForOptionalFields(t *testing.T) {
@@ -118,6 +124,15 @@ func TestNewDatabaseConfig_UsesDefaultsForOptionalFields(t *testing.T) {
 	if cfg.SSLMode != "disable" {
 		t.Errorf("SSLMode default = %q, want %q", cfg.SSLMode, "disable")
 	}
+	if cfg.AutoMigrate {
+		t.Error("AutoMigrate default = true, want false")
+	}
+	if cfg.MaxOpenConns != 25 || cfg.MaxIdleConns != 10 {
+		t.Errorf("pool size defaults = %d open, %d idle, want 25 and 10", cfg.MaxOpenConns, cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime != time.Hour || cfg.ConnMaxIdleTime != 10*time.Minute {
+		t.Errorf("connection lifetime defaults = %v, %v, want 1h and 10m", cfg.ConnMaxLifetime, cfg.ConnMaxIdleTime)
+	}
 }
 
 func TestNewDatabaseConfig_PanicsOnMissingHost(t *testing.T) {
@@ -183,3 +198,34 @@ func TestNewDatabaseConfig_PanicsOnMissingName(t *testing.T) {
 
 	NewDatabaseConfig()
 }
+
+func TestNewDatabaseConfig_PanicsOnInvalidPool(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		val  string
+	}{
+		{name: "no open connections", key: "DB_MAX_OPEN_CONNS", val: "0"},
+		{name: "more idle than open", key: "DB_MAX_IDLE_CONNS", val: "30"},
+		{name: "negative lifetime", key: "DB_CONN_MAX_LIFETIME", val: "-1m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllDatabaseEnvVars(t)
+			setEnvForTest(t, "DB_HOST", "localhost")
+			setEnvForTest(t, "DB_USER", "user")
+			setEnvForTest(t, "DB_PASSWORD", "pass")
+			setEnvForTest(t, "DB_NAME", "db")
+			setEnvForTest(t, tt.key, tt.val)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewDatabaseConfig() should panic for %s=%s", tt.key, tt.val)
+				}
+			}()
+
+			NewDatabaseConfig()
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// HealthConfig holds configuration of the health probes
+type HealthConfig struct {
+	CheckTimeout  time.Duration `validate:"gt=0"`  // Longest a single dependency check may take
+	CacheTTL      time.Duration `validate:"gte=0"` // How long check results are reused
+	ShutdownDelay time.Duration `validate:"gte=0"` // Time between readiness failing and the server closing
+
+	// CheckMigrations makes readiness and startup wait for all migrations.
+	// Disable it when the schema is not managed by this service's migrations.
+	CheckMigrations bool
+}
+
+// NewHealthConfig loads health probe configuration from environment variables
+func NewHealthConfig() HealthConfig {
+	cfg := HealthConfig{
+		CheckTimeout:    utils.GetEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		CacheTTL:        utils.GetEnvDuration("HEALTH_CACHE_TTL", time.Second),
+		ShutdownDelay:   utils.GetEnvDuration("HEALTH_SHUTDOWN_DELAY", 0),
+		CheckMigrations: utils.GetEnvBool("HEALTH_CHECK_MIGRATIONS", true),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid health configuration: %v", err))
+	}
+
+	return cfg
+}
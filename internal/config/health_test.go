@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// NewHealthConfig Tests
+// =============================================================================
+
+func TestNewHealthConfig_UsesDefaults(t *testing.T) {
+	for _, v := range []string{"HEALTH_CHECK_TIMEOUT", "HEALTH_CACHE_TTL", "HEALTH_SHUTDOWN_DELAY", "HEALTH_CHECK_MIGRATIONS"} {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+
+	cfg := NewHealthConfig()
+
+	if cfg.CheckTimeout != 2*time.Second || cfg.CacheTTL != time.Second || cfg.ShutdownDelay != 0 || !cfg.CheckMigrations {
+		t.Errorf("NewHealthConfig() = %+v, want 2s timeout, 1s cache, no shutdown delay and migrations checked", cfg)
+	}
+}
+
+func TestNewHealthConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "zero timeout", key: "HEALTH_CHECK_TIMEOUT", value: "0s"},
+		{name: "negative cache", key: "HEALTH_CACHE_TTL", value: "-1s"},
+		{name: "negative shutdown delay", key: "HEALTH_SHUTDOWN_DELAY", value: "-5s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnvForTest(t, tt.key, tt.value)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewHealthConfig() should panic")
+				}
+			}()
+			NewHealthConfig()
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"crypto"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -10,40 +12,124 @@ import (
 	"github.com/GunarsK-templates/template-api/internal/utils"
 )
 
-// JWTConfig holds JWT authentication configuration
+// JWTConfig holds JWT authentication configuration.
+// Tokens are signed with SigningKey when set (RS256/ES256/EdDSA), otherwise with Secret (HS256).
 type JWTConfig struct {
-	Secret        string        `validate:"required,min=32"`
-	AccessExpiry  time.Duration `validate:"gt=0"`
-	RefreshExpiry time.Duration `validate:"gt=0"`
+	Secret           string        `validate:"omitempty,min=32"`
+	SigningKey       crypto.Signer // Optional: asymmetric private key used to sign tokens
+	SigningKeyID     string        // "kid" header of tokens signed with SigningKey
+	VerificationKeys map[string]crypto.PublicKey
+	AccessExpiry     time.Duration `validate:"gt=0"`
+	RefreshExpiry    time.Duration `validate:"gt=0"`
+	Issuer           string        // Optional: "iss" of locally signed tokens, written and expected. Empty skips the check.
+	Audience         string        `validate:"required_with=IssuerURL"` // Expected "aud" claim. Empty skips the check (required with IssuerURL).
+	Leeway           time.Duration `validate:"gte=0"`
+
+	// Claim mapping for authorization; dot-separated paths reach nested claims
+	RolesClaim  string `validate:"required"`
+	ScopesClaim string `validate:"required"`
+
+	// OIDC verification: tokens from an external issuer are verified
+	// against the keys advertised by its discovery document
+	IssuerURL           string        `validate:"omitempty,url"`
+	JWKSRefreshInterval time.Duration `validate:"gt=0"`
 }
 
 // NewJWTConfig loads JWT configuration from environment variables.
-// Returns nil if JWT_SECRET is not set (JWT is optional).
+// Returns nil if none of JWT_SECRET, JWT_PRIVATE_KEY_FILE, JWT_PUBLIC_KEY_FILES
+// or JWT_ISSUER_URL is set (JWT is optional).
+//
+// Asymmetric keys:
+//   - JWT_PRIVATE_KEY_FILE: PEM private key (RSA, ECDSA P-256 or Ed25519) used for signing
+//   - JWT_KEY_ID: "kid" of the signing key (default: derived from the public key)
+//   - JWT_PUBLIC_KEY_FILES: additional verification keys as comma-separated
+//     kid=path pairs, e.g. keys retired during rotation
+//
+// External OIDC issuer:
+//   - JWT_ISSUER_URL: issuer whose /.well-known/openid-configuration is used
+//     to find its JWKS; tokens verified with its keys must carry its "iss".
+//     JWT_ISSUER only applies to locally signed tokens; when tokens are also
+//     signed locally it must differ from JWT_ISSUER_URL.
+//   - JWT_AUDIENCE: required with JWT_ISSUER_URL
+//   - JWT_JWKS_REFRESH_INTERVAL: 15m (background key refresh)
+//
 // Default values:
 //   - JWT_ACCESS_EXPIRY: 15m (15 minutes)
 //   - JWT_REFRESH_EXPIRY: 168h (7 days)
+//   - JWT_LEEWAY: 30s (clock skew tolerance for exp/nbf)
+//   - JWT_ROLES_CLAIM: roles (e.g. realm_access.roles for Keycloak)
+//   - JWT_SCOPES_CLAIM: scope (space-delimited string or array, e.g. scp)
 func NewJWTConfig() *JWTConfig {
 	// JWT is optional - return nil if not configured
 	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
+	privateKeyFile := os.Getenv("JWT_PRIVATE_KEY_FILE")
+	publicKeyFiles := utils.GetEnvSlice("JWT_PUBLIC_KEY_FILES", nil)
+	issuerURL := os.Getenv("JWT_ISSUER_URL")
+	if secret == "" && privateKeyFile == "" && len(publicKeyFiles) == 0 && issuerURL == "" {
 		return nil
 	}
 
 	cfg := &JWTConfig{
-		Secret:        secret,
-		AccessExpiry:  utils.GetEnvDuration("JWT_ACCESS_EXPIRY", 15*time.Minute),
-		RefreshExpiry: utils.GetEnvDuration("JWT_REFRESH_EXPIRY", 168*time.Hour),
+		Secret:           secret,
+		VerificationKeys: make(map[string]crypto.PublicKey),
+		AccessExpiry:     utils.GetEnvDuration("JWT_ACCESS_EXPIRY", 15*time.Minute),
+		RefreshExpiry:    utils.GetEnvDuration("JWT_REFRESH_EXPIRY", 168*time.Hour),
+		Issuer:           utils.GetEnv("JWT_ISSUER", ""),
+		Audience:         utils.GetEnv("JWT_AUDIENCE", ""),
+		Leeway:           utils.GetEnvDuration("JWT_LEEWAY", 30*time.Second),
+
+		RolesClaim:  utils.GetEnv("JWT_ROLES_CLAIM", "roles"),
+		ScopesClaim: utils.GetEnv("JWT_SCOPES_CLAIM", "scope"),
+
+		IssuerURL:           issuerURL,
+		JWKSRefreshInterval: utils.GetEnvDuration("JWT_JWKS_REFRESH_INTERVAL", 15*time.Minute),
+	}
+
+	if privateKeyFile != "" {
+		key, err := LoadPrivateKey(privateKeyFile)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid JWT configuration: %v", err))
+		}
+		cfg.SigningKey = key
+		cfg.SigningKeyID = utils.GetEnv("JWT_KEY_ID", KeyID(key.Public()))
+		cfg.VerificationKeys[cfg.SigningKeyID] = key.Public()
+	}
+
+	for _, entry := range publicKeyFiles {
+		kid, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || kid == "" || path == "" {
+			panic(fmt.Sprintf("Invalid JWT configuration: JWT_PUBLIC_KEY_FILES entry %q must be kid=path", entry))
+		}
+		if _, exists := cfg.VerificationKeys[kid]; exists {
+			panic(fmt.Sprintf("Invalid JWT configuration: duplicate key id %q", kid))
+		}
+		key, err := LoadPublicKey(path)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid JWT configuration: %v", err))
+		}
+		cfg.VerificationKeys[kid] = key
 	}
 
 	validate := validator.New()
 	if err := validate.Struct(cfg); err != nil {
 		panic(fmt.Sprintf("Invalid JWT configuration: %v", err))
 	}
+	// Locally signed tokens must be distinguishable from the identity provider's
+	if cfg.CanSign() && cfg.IssuerURL != "" &&
+		strings.TrimSuffix(cfg.Issuer, "/") == strings.TrimSuffix(cfg.IssuerURL, "/") {
+		panic("Invalid JWT configuration: JWT_ISSUER must differ from JWT_ISSUER_URL")
+	}
 
 	return cfg
 }
 
 // HasJWT returns true if JWT authentication is configured
 func (c *JWTConfig) HasJWT() bool {
-	return c != nil && c.Secret != ""
+	return c != nil && (c.Secret != "" || len(c.VerificationKeys) > 0 || c.IssuerURL != "")
+}
+
+// CanSign returns true if tokens can be issued (secret or private key configured).
+// A config with only public keys verifies tokens issued elsewhere.
+func (c *JWTConfig) CanSign() bool {
+	return c != nil && (c.Secret != "" || c.SigningKey != nil)
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto"
+	"crypto/elliptic"
 	"os"
 	"testing"
 	"time"
@@ -18,7 +20,13 @@ const (
 // clearAllJWTEnvVars clears all JWT-related environment variables.
 func clearAllJWTEnvVars(t *testing.T) {
 	t.Helper()
-	vars := []string{"JWT_SECRET", "JWT_ACCESS_EXPIRY", "JWT_REFRESH_EXPIRY"}
+	vars := []string{
+		"JWT_SECRET", "JWT_ACCESS_EXPIRY", "JWT_REFRESH_EXPIRY",
+		"JWT_ISSUER", "JWT_AUDIENCE", "JWT_LEEWAY",
+		"JWT_PRIVATE_KEY_FILE", "JWT_KEY_ID", "JWT_PUBLIC_KEY_FILES",
+		"JWT_ISSUER_URL", "JWT_JWKS_REFRESH_INTERVAL",
+		"JWT_ROLES_CLAIM", "JWT_SCOPES_CLAIM",
+	}
 	for _, v := range vars {
 		t.Setenv(v, "")
 		os.Unsetenv(v) //nolint:errcheck // test cleanup
@@ -45,6 +53,11 @@ func TestNewJWTConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	setEnvForTest(t, "JWT_SECRET", testJWTSecret)
 	setEnvForTest(t, "JWT_ACCESS_EXPIRY", "30m")
 	setEnvForTest(t, "JWT_REFRESH_EXPIRY", "24h")
+	setEnvForTest(t, "JWT_ISSUER", "my-issuer")
+	setEnvForTest(t, "JWT_AUDIENCE", "my-audience")
+	setEnvForTest(t, "JWT_LEEWAY", "5s")
+	setEnvForTest(t, "JWT_ROLES_CLAIM", "realm_access.roles")
+	setEnvForTest(t, "JWT_SCOPES_CLAIM", "scp")
 
 	cfg := NewJWTConfig()
 
@@ -60,6 +73,21 @@ func TestNewJWTConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	if cfg.RefreshExpiry != 24*time.Hour {
 		t.Errorf("RefreshExpiry = %v, want %v", cfg.RefreshExpiry, 24*time.Hour)
 	}
+	if cfg.Issuer != "my-issuer" {
+		t.Errorf("Issuer = %q, want %q", cfg.Issuer, "my-issuer")
+	}
+	if cfg.Audience != "my-audience" {
+		t.Errorf("Audience = %q, want %q", cfg.Audience, "my-audience")
+	}
+	if cfg.Leeway != 5*time.Second {
+		t.Errorf("Leeway = %v, want %v", cfg.Leeway, 5*time.Second)
+	}
+	if cfg.RolesClaim != "realm_access.roles" {
+		t.Errorf("RolesClaim = %q, want %q", cfg.RolesClaim, "realm_access.roles")
+	}
+	if cfg.ScopesClaim != "scp" {
+		t.Errorf("ScopesClaim = %q, want %q", cfg.ScopesClaim, "scp")
+	}
 }
 
 func TestNewJWTConfig_UsesDefaultsForOptionalFields(t *testing.T) {
@@ -78,6 +106,15 @@ func TestNewJWTConfig_UsesDefaultsForOptionalFields(t *testing.T) {
 	if cfg.RefreshExpiry != 168*time.Hour {
 		t.Errorf("RefreshExpiry default = %v, want %v", cfg.RefreshExpiry, 168*time.Hour)
 	}
+	if cfg.Issuer != "" || cfg.Audience != "" {
+		t.Errorf("Issuer/Audience defaults = %q/%q, want empty", cfg.Issuer, cfg.Audience)
+	}
+	if cfg.Leeway != 30*time.Second {
+		t.Errorf("Leeway default = %v, want %v", cfg.Leeway, 30*time.Second)
+	}
+	if cfg.RolesClaim != "roles" || cfg.ScopesClaim != "scope" {
+		t.Errorf("claim defaults = %q/%q, want roles/scope", cfg.RolesClaim, cfg.ScopesClaim)
+	}
 }
 
 func TestNewJWTConfig_PanicsOnShortSecret(t *testing.T) {
@@ -94,6 +131,137 @@ func TestNewJWTConfig_PanicsOnShortSecret(t *testing.T) {
 	NewJWTConfig()
 }
 
+func TestNewJWTConfig_LoadsSigningKey(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	key := mustGenerateECKey(t, elliptic.P256())
+	setEnvForTest(t, "JWT_PRIVATE_KEY_FILE", writePKCS8Key(t, key))
+
+	cfg := NewJWTConfig()
+
+	if cfg == nil {
+		t.Fatal("NewJWTConfig() returned nil")
+	}
+	if !cfg.HasJWT() || !cfg.CanSign() {
+		t.Error("config with private key should verify and sign")
+	}
+	if cfg.SigningKeyID != KeyID(key.Public()) {
+		t.Errorf("SigningKeyID = %q, want derived %q", cfg.SigningKeyID, KeyID(key.Public()))
+	}
+	if !key.PublicKey.Equal(cfg.VerificationKeys[cfg.SigningKeyID]) {
+		t.Error("signing key's public key should be a verification key")
+	}
+}
+
+func TestNewJWTConfig_LoadsRotationKeysWithExplicitKeyID(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	current := mustGenerateECKey(t, elliptic.P256())
+	previous := mustGenerateECKey(t, elliptic.P256())
+	setEnvForTest(t, "JWT_PRIVATE_KEY_FILE", writePKCS8Key(t, current))
+	setEnvForTest(t, "JWT_KEY_ID", "2025-02")
+	setEnvForTest(t, "JWT_PUBLIC_KEY_FILES", "2025-01="+writePublicKey(t, previous.Public()))
+
+	cfg := NewJWTConfig()
+
+	if cfg.SigningKeyID != "2025-02" {
+		t.Errorf("SigningKeyID = %q, want %q", cfg.SigningKeyID, "2025-02")
+	}
+	if len(cfg.VerificationKeys) != 2 {
+		t.Fatalf("VerificationKeys length = %d, want 2", len(cfg.VerificationKeys))
+	}
+	if !previous.PublicKey.Equal(cfg.VerificationKeys["2025-01"]) {
+		t.Error("rotation key was not loaded under its key ID")
+	}
+}
+
+func TestNewJWTConfig_PublicKeysOnlyCannotSign(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	key := mustGenerateECKey(t, elliptic.P256())
+	setEnvForTest(t, "JWT_PUBLIC_KEY_FILES", "issuer-key="+writePublicKey(t, key.Public()))
+
+	cfg := NewJWTConfig()
+
+	if !cfg.HasJWT() {
+		t.Error("HasJWT() should be true with verification keys")
+	}
+	if cfg.CanSign() {
+		t.Error("CanSign() should be false without secret or private key")
+	}
+}
+
+func TestNewJWTConfig_PanicsOnMalformedPublicKeyEntry(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	setEnvForTest(t, "JWT_PUBLIC_KEY_FILES", "missing-equals-sign")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewJWTConfig() should panic for entries without kid=path")
+		}
+	}()
+
+	NewJWTConfig()
+}
+
+func TestNewJWTConfig_LoadsOIDCIssuer(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	setEnvForTest(t, "JWT_ISSUER_URL", "https://idp.example.com")
+	setEnvForTest(t, "JWT_AUDIENCE", "my-api")
+	setEnvForTest(t, "JWT_JWKS_REFRESH_INTERVAL", "5m")
+
+	cfg := NewJWTConfig()
+
+	if cfg == nil {
+		t.Fatal("NewJWTConfig() returned nil")
+	}
+	if cfg.IssuerURL != "https://idp.example.com" {
+		t.Errorf("IssuerURL = %q, want %q", cfg.IssuerURL, "https://idp.example.com")
+	}
+	if cfg.Issuer != "" {
+		t.Errorf("Issuer default = %q, want none (the issuer URL only applies to its own tokens)", cfg.Issuer)
+	}
+	if cfg.JWKSRefreshInterval != 5*time.Minute {
+		t.Errorf("JWKSRefreshInterval = %v, want %v", cfg.JWKSRefreshInterval, 5*time.Minute)
+	}
+	if !cfg.HasJWT() || cfg.CanSign() {
+		t.Error("OIDC-only config should verify but not sign")
+	}
+}
+
+func TestNewJWTConfig_PanicsOnOIDCIssuerWithoutAudience(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	setEnvForTest(t, "JWT_ISSUER_URL", "https://idp.example.com")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewJWTConfig() should panic when JWT_AUDIENCE is missing for an OIDC issuer")
+		}
+	}()
+
+	NewJWTConfig()
+}
+
+func TestNewJWTConfig_PanicsOnLocalIssuerMatchingOIDCIssuer(t *testing.T) {
+	clearAllJWTEnvVars(t)
+
+	setEnvForTest(t, "JWT_SECRET", testJWTSecret)
+	setEnvForTest(t, "JWT_ISSUER_URL", "https://idp.example.com")
+	setEnvForTest(t, "JWT_AUDIENCE", "my-api")
+	setEnvForTest(t, "JWT_ISSUER", "https://idp.example.com/")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewJWTConfig() should panic when local tokens would carry the OIDC issuer")
+		}
+	}()
+
+	NewJWTConfig()
+}
+
 // =============================================================================
 // JWTConfig.HasJWT Tests
 // =============================================================================
@@ -119,6 +287,16 @@ func TestJWTConfig_HasJWT_TableDriven(t *testing.T) {
 			cfg:  &JWTConfig{Secret: "my-secret"},
 			want: true,
 		},
+		{
+			name: "returns true for OIDC issuer only",
+			cfg:  &JWTConfig{IssuerURL: "https://idp.example.com"},
+			want: true,
+		},
+		{
+			name: "returns true for verification keys only",
+			cfg:  &JWTConfig{VerificationKeys: map[string]crypto.PublicKey{"kid": nil}},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
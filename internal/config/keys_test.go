@@ -0,0 +1,174 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// writePEM writes a single PEM block to a temporary file and returns its path.
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	return path
+}
+
+// writePKCS8Key writes a private key as PKCS#8 PEM and returns its path.
+func writePKCS8Key(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+	return writePEM(t, "PRIVATE KEY", der)
+}
+
+// writePublicKey writes a public key as PKIX PEM and returns its path.
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return writePEM(t, "PUBLIC KEY", der)
+}
+
+// mustGenerateECKey generates an ECDSA key on the given curve.
+func mustGenerateECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return key
+}
+
+// mustGenerateRSAKey generates an RSA key of the given size.
+func mustGenerateRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+// =============================================================================
+// LoadPrivateKey Tests
+// =============================================================================
+
+func TestLoadPrivateKey_SupportedFormats_TableDriven(t *testing.T) {
+	rsaKey := mustGenerateRSAKey(t, 2048)
+	ecKey := mustGenerateECKey(t, elliptic.P256())
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("failed to marshal EC key: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "RSA PKCS#8", path: writePKCS8Key(t, rsaKey)},
+		{name: "RSA PKCS#1", path: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))},
+		{name: "ECDSA PKCS#8", path: writePKCS8Key(t, ecKey)},
+		{name: "ECDSA SEC 1", path: writePEM(t, "EC PRIVATE KEY", ecDER)},
+		{name: "Ed25519 PKCS#8", path: writePKCS8Key(t, edKey)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := LoadPrivateKey(tt.path)
+
+			if err != nil {
+				t.Fatalf("LoadPrivateKey() error = %v", err)
+			}
+			if key == nil {
+				t.Fatal("LoadPrivateKey() returned nil key")
+			}
+		})
+	}
+}
+
+func TestLoadPrivateKey_RejectsUnsupportedKeys_TableDriven(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "RSA below 2048 bits", path: writePKCS8Key(t, mustGenerateRSAKey(t, 1024))},
+		{name: "ECDSA P-384", path: writePKCS8Key(t, mustGenerateECKey(t, elliptic.P384()))},
+		{name: "not PEM", path: writePEM(t, "PRIVATE KEY", []byte("garbage"))},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.pem")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadPrivateKey(tt.path); err == nil {
+				t.Error("LoadPrivateKey() should return an error")
+			}
+		})
+	}
+}
+
+// =============================================================================
+// LoadPublicKey Tests
+// =============================================================================
+
+func TestLoadPublicKey_LoadsPKIXAndPKCS1(t *testing.T) {
+	rsaKey := mustGenerateRSAKey(t, 2048)
+
+	paths := []string{
+		writePublicKey(t, rsaKey.Public()),
+		writePEM(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)),
+	}
+
+	for _, path := range paths {
+		key, err := LoadPublicKey(path)
+		if err != nil {
+			t.Fatalf("LoadPublicKey() error = %v", err)
+		}
+		if !rsaKey.PublicKey.Equal(key) {
+			t.Error("LoadPublicKey() returned a different key")
+		}
+	}
+}
+
+// =============================================================================
+// KeyID Tests
+// =============================================================================
+
+func TestKeyID_IsStableAndDistinct(t *testing.T) {
+	a := mustGenerateECKey(t, elliptic.P256())
+	b := mustGenerateECKey(t, elliptic.P256())
+
+	if KeyID(a.Public()) != KeyID(a.Public()) {
+		t.Error("KeyID() should be stable for the same key")
+	}
+	if KeyID(a.Public()) == KeyID(b.Public()) {
+		t.Error("KeyID() should differ for different keys")
+	}
+	if KeyID(a.Public()) == "" {
+		t.Error("KeyID() should not be empty")
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// MetricsConfig holds configuration of the Prometheus HTTP metrics
+type MetricsConfig struct {
+	DurationBuckets []float64 `validate:"min=1,dive,gt=0"` // Request duration histogram buckets in seconds
+	SizeBuckets     []float64 `validate:"min=1,dive,gt=0"` // Response size histogram buckets in bytes
+	QueryBuckets    []float64 `validate:"min=1,dive,gt=0"` // Database query duration histogram buckets in seconds
+	ExcludePaths    []string  // Routes not recorded, including routes below them
+}
+
+// NewMetricsConfig loads metrics configuration from environment variables
+func NewMetricsConfig() MetricsConfig {
+	cfg := MetricsConfig{
+		DurationBuckets: utils.GetEnvFloatSlice("METRICS_DURATION_BUCKETS",
+			[]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}),
+		SizeBuckets: utils.GetEnvFloatSlice("METRICS_SIZE_BUCKETS",
+			[]float64{100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000}),
+		QueryBuckets: utils.GetEnvFloatSlice("METRICS_QUERY_BUCKETS",
+			[]float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}),
+		ExcludePaths: utils.GetEnvSlice("METRICS_EXCLUDE_PATHS", []string{"/metrics", "/health"}),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid metrics configuration: %v", err))
+	}
+	// Histograms need strictly increasing buckets
+	for _, buckets := range [][]float64{cfg.DurationBuckets, cfg.SizeBuckets, cfg.QueryBuckets} {
+		if !slices.IsSorted(buckets) || len(slices.Compact(slices.Clone(buckets))) != len(buckets) {
+			panic(fmt.Sprintf("Invalid metrics configuration: buckets %v must be increasing", buckets))
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// =============================================================================
+// NewMetricsConfig Tests
+// =============================================================================
+
+func TestNewMetricsConfig_UsesDefaults(t *testing.T) {
+	for _, v := range []string{"METRICS_DURATION_BUCKETS", "METRICS_SIZE_BUCKETS", "METRICS_QUERY_BUCKETS", "METRICS_EXCLUDE_PATHS"} {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+
+	cfg := NewMetricsConfig()
+
+	if len(cfg.DurationBuckets) != 11 || len(cfg.SizeBuckets) != 6 || len(cfg.QueryBuckets) != 11 {
+		t.Errorf("NewMetricsConfig() buckets = %v, %v, %v, want the default buckets", cfg.DurationBuckets, cfg.SizeBuckets, cfg.QueryBuckets)
+	}
+	if len(cfg.ExcludePaths) != 2 || cfg.ExcludePaths[0] != "/metrics" || cfg.ExcludePaths[1] != "/health" {
+		t.Errorf("NewMetricsConfig() ExcludePaths = %v, want [/metrics /health]", cfg.ExcludePaths)
+	}
+}
+
+func TestNewMetricsConfig_LoadsBuckets(t *testing.T) {
+	setEnvForTest(t, "METRICS_DURATION_BUCKETS", "0.1,0.5,1")
+
+	cfg := NewMetricsConfig()
+
+	if len(cfg.DurationBuckets) != 3 || cfg.DurationBuckets[2] != 1 {
+		t.Errorf("NewMetricsConfig() DurationBuckets = %v, want [0.1 0.5 1]", cfg.DurationBuckets)
+	}
+}
+
+func TestNewMetricsConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "unsorted buckets", key: "METRICS_DURATION_BUCKETS", value: "1,0.5"},
+		{name: "duplicate buckets", key: "METRICS_SIZE_BUCKETS", value: "100,100"},
+		{name: "negative bucket", key: "METRICS_DURATION_BUCKETS", value: "-1,1"},
+		{name: "unsorted query buckets", key: "METRICS_QUERY_BUCKETS", value: "0.1,0.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnvForTest(t, tt.key, tt.value)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewMetricsConfig() should panic")
+				}
+			}()
+			NewMetricsConfig()
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// Outbox publishers
+const (
+	OutboxPublisherLog     = "log"
+	OutboxPublisherWebhook = "webhook"
+)
+
+// OutboxConfig holds configuration of the relay that publishes item events from the outbox
+type OutboxConfig struct {
+	// Publisher is where events go: "log" writes them to the service log,
+	// "webhook" POSTs them to WebhookURL
+	Publisher      string        `validate:"required,oneof=log webhook"`
+	WebhookURL     string        `validate:"required_if=Publisher webhook,omitempty,url"`
+	WebhookTimeout time.Duration `validate:"gte=1s"`
+
+	// The relay checks for due events every PollInterval and publishes up to BatchSize
+	// at a time. Failed events are retried with exponential backoff up to MaxBackoff.
+	PollInterval time.Duration `validate:"gte=100ms"`
+	BatchSize    int           `validate:"min=1,max=1000"`
+	MaxBackoff   time.Duration `validate:"gte=1s"`
+}
+
+// NewOutboxConfig loads outbox configuration from environment variables
+func NewOutboxConfig() OutboxConfig {
+	cfg := OutboxConfig{
+		Publisher:      utils.GetEnv("OUTBOX_PUBLISHER", OutboxPublisherLog),
+		WebhookURL:     utils.GetEnv("OUTBOX_WEBHOOK_URL", ""),
+		WebhookTimeout: utils.GetEnvDuration("OUTBOX_WEBHOOK_TIMEOUT", 10*time.Second),
+
+		PollInterval: utils.GetEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		BatchSize:    utils.GetEnvInt("OUTBOX_BATCH_SIZE", 100),
+		MaxBackoff:   utils.GetEnvDuration("OUTBOX_MAX_BACKOFF", time.Hour),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid outbox configuration: %v", err))
+	}
+
+	return cfg
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// clearAllOutboxEnvVars clears all outbox-related environment variables.
+func clearAllOutboxEnvVars(t *testing.T) {
+	t.Helper()
+	vars := []string{"OUTBOX_PUBLISHER", "OUTBOX_WEBHOOK_URL", "OUTBOX_WEBHOOK_TIMEOUT", "OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_MAX_BACKOFF"}
+	for _, v := range vars {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+}
+
+// =============================================================================
+// NewOutboxConfig Tests
+// =============================================================================
+
+func TestNewOutboxConfig_UsesDefaults(t *testing.T) {
+	clearAllOutboxEnvVars(t)
+
+	cfg := NewOutboxConfig()
+
+	want := OutboxConfig{
+		Publisher:      OutboxPublisherLog,
+		WebhookTimeout: 10 * time.Second,
+		PollInterval:   time.Second,
+		BatchSize:      100,
+		MaxBackoff:     time.Hour,
+	}
+	if cfg != want {
+		t.Errorf("NewOutboxConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestNewOutboxConfig_LoadsWebhookFromEnv(t *testing.T) {
+	clearAllOutboxEnvVars(t)
+	setEnvForTest(t, "OUTBOX_PUBLISHER", "webhook")
+	setEnvForTest(t, "OUTBOX_WEBHOOK_URL", "https://hooks.example.com/items")
+	setEnvForTest(t, "OUTBOX_WEBHOOK_TIMEOUT", "3s")
+	setEnvForTest(t, "OUTBOX_POLL_INTERVAL", "250ms")
+	setEnvForTest(t, "OUTBOX_BATCH_SIZE", "10")
+	setEnvForTest(t, "OUTBOX_MAX_BACKOFF", "5m")
+
+	cfg := NewOutboxConfig()
+
+	want := OutboxConfig{
+		Publisher:      OutboxPublisherWebhook,
+		WebhookURL:     "https://hooks.example.com/items",
+		WebhookTimeout: 3 * time.Second,
+		PollInterval:   250 * time.Millisecond,
+		BatchSize:      10,
+		MaxBackoff:     5 * time.Minute,
+	}
+	if cfg != want {
+		t.Errorf("NewOutboxConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestNewOutboxConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{name: "unknown publisher", env: map[string]string{"OUTBOX_PUBLISHER": "kafka"}},
+		{name: "webhook without URL", env: map[string]string{"OUTBOX_PUBLISHER": "webhook"}},
+		{name: "invalid webhook URL", env: map[string]string{"OUTBOX_PUBLISHER": "webhook", "OUTBOX_WEBHOOK_URL": "not a url"}},
+		{name: "batch size too large", env: map[string]string{"OUTBOX_BATCH_SIZE": "5000"}},
+		{name: "poll interval too short", env: map[string]string{"OUTBOX_POLL_INTERVAL": "1ms"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllOutboxEnvVars(t)
+			for k, v := range tt.env {
+				setEnvForTest(t, k, v)
+			}
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewOutboxConfig() should panic")
+				}
+			}()
+			NewOutboxConfig()
+		})
+	}
+}
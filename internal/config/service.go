@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -16,6 +17,12 @@ type ServiceConfig struct {
 	Environment    string   `validate:"required,oneof=development staging production"`
 	AllowedOrigins []string `validate:"required,min=1"`
 	SwaggerHost    string   // Optional: Swagger UI host. Empty disables swagger.
+	RequireIfMatch bool     // Reject item updates/deletes without If-Match (428)
+
+	// Deleted items stay in the trash for TrashRetention, then a background
+	// purger removes them every TrashPurgeInterval. Zero retention disables purging.
+	TrashRetention     time.Duration `validate:"gte=0"`
+	TrashPurgeInterval time.Duration `validate:"gte=1m"`
 }
 
 // NewServiceConfig loads service configuration from environment variables
@@ -37,6 +44,10 @@ func NewServiceConfig() ServiceConfig {
 		Environment:    utils.GetEnv("ENVIRONMENT", "development"),
 		AllowedOrigins: allowedOrigins,
 		SwaggerHost:    utils.GetEnv("SWAGGER_HOST", ""),
+		RequireIfMatch: utils.GetEnvBool("REQUIRE_IF_MATCH", false),
+
+		TrashRetention:     utils.GetEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: utils.GetEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
 	}
 
 	validate := validator.New()
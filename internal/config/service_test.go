@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -12,7 +13,7 @@ import (
 // clearAllServiceEnvVars clears all service-related environment variables.
 func clearAllServiceEnvVars(t *testing.T) {
 	t.Helper()
-	vars := []string{"SERVICE_NAME", "PORT", "ENVIRONMENT", "ALLOWED_ORIGINS", "SWAGGER_HOST"}
+	vars := []string{"SERVICE_NAME", "PORT", "ENVIRONMENT", "ALLOWED_ORIGINS", "SWAGGER_HOST", "REQUIRE_IF_MATCH", "TRASH_RETENTION", "TRASH_PURGE_INTERVAL"}
 	for _, v := range vars {
 		t.Setenv(v, "")
 		os.Unsetenv(v) //nolint:errcheck // test cleanup
@@ -31,6 +32,9 @@ func TestNewServiceConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	setEnvForTest(t, "ENVIRONMENT", "production")
 	setEnvForTest(t, "ALLOWED_ORIGINS", "https://example.com,https://api.example.com")
 	setEnvForTest(t, "SWAGGER_HOST", "api.example.com")
+	setEnvForTest(t, "REQUIRE_IF_MATCH", "true")
+	setEnvForTest(t, "TRASH_RETENTION", "0s")
+	setEnvForTest(t, "TRASH_PURGE_INTERVAL", "5m")
 
 	cfg := NewServiceConfig()
 
@@ -49,6 +53,15 @@ func TestNewServiceConfig_LoadsAllFieldsFromEnv(t *testing.T) {
 	if cfg.SwaggerHost != "api.example.com" {
 		t.Errorf("SwaggerHost = %q, want %q", cfg.SwaggerHost, "api.example.com")
 	}
+	if !cfg.RequireIfMatch {
+		t.Error("RequireIfMatch = false, want true")
+	}
+	if cfg.TrashRetention != 0 {
+		t.Errorf("TrashRetention = %v, want 0", cfg.TrashRetention)
+	}
+	if cfg.TrashPurgeInterval != 5*time.Minute {
+		t.Errorf("TrashPurgeInterval = %v, want %v", cfg.TrashPurgeInterval, 5*time.Minute)
+	}
 }
 
 func TestNewServiceConfig_UsesDefaultsForOptionalFields(t *testing.T) {
@@ -71,6 +84,15 @@ func TestNewServiceConfig_UsesDefaultsForOptionalFields(t *testing.T) {
 	if cfg.SwaggerHost != "" {
 		t.Errorf("SwaggerHost default = %q, want empty string", cfg.SwaggerHost)
 	}
+	if cfg.RequireIfMatch {
+		t.Error("RequireIfMatch default = true, want false")
+	}
+	if cfg.TrashRetention != 30*24*time.Hour {
+		t.Errorf("TrashRetention default = %v, want %v", cfg.TrashRetention, 30*24*time.Hour)
+	}
+	if cfg.TrashPurgeInterval != time.Hour {
+		t.Errorf("TrashPurgeInterval default = %v, want %v", cfg.TrashPurgeInterval, time.Hour)
+	}
 }
 
 // =============================================================================
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// StreamConfig holds configuration of the Server-Sent Events stream of item changes
+type StreamConfig struct {
+	BufferSize        int           `validate:"min=1,max=100000"` // Latest events kept for resuming clients
+	HeartbeatInterval time.Duration `validate:"gte=1s"`           // Comment sent to idle streams to keep proxies from closing them
+}
+
+// NewStreamConfig loads stream configuration from environment variables
+func NewStreamConfig() StreamConfig {
+	cfg := StreamConfig{
+		BufferSize:        utils.GetEnvInt("SSE_BUFFER_SIZE", 1000),
+		HeartbeatInterval: utils.GetEnvDuration("SSE_HEARTBEAT_INTERVAL", 15*time.Second),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid stream configuration: %v", err))
+	}
+
+	return cfg
+}
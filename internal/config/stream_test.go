@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// NewStreamConfig Tests
+// =============================================================================
+
+func TestNewStreamConfig_UsesDefaults(t *testing.T) {
+	for _, v := range []string{"SSE_BUFFER_SIZE", "SSE_HEARTBEAT_INTERVAL"} {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+
+	cfg := NewStreamConfig()
+
+	if cfg.BufferSize != 1000 || cfg.HeartbeatInterval != 15*time.Second {
+		t.Errorf("NewStreamConfig() = %+v, want 1000 events and 15s heartbeats", cfg)
+	}
+}
+
+func TestNewStreamConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "empty buffer", key: "SSE_BUFFER_SIZE", value: "0"},
+		{name: "heartbeat too frequent", key: "SSE_HEARTBEAT_INTERVAL", value: "100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnvForTest(t, tt.key, tt.value)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewStreamConfig() should panic")
+				}
+			}()
+			NewStreamConfig()
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// Tracing exporters
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	// Exporter is where spans go: "otlp" sends them over OTLP/HTTP, "stdout"
+	// prints them (development), "none" disables tracing
+	Exporter string `validate:"required,oneof=none otlp stdout"`
+
+	// OTLPEndpoint is the collector URL, e.g. http://otel-collector:4318.
+	// Empty uses OTEL_EXPORTER_OTLP_ENDPOINT or http://localhost:4318.
+	OTLPEndpoint string `validate:"omitempty,url"`
+
+	// SampleRatio is the share of new traces recorded; requests continuing a
+	// trace follow the caller's sampling decision
+	SampleRatio float64 `validate:"gte=0,lte=1"`
+
+	// ExcludePaths are routes not traced, including routes below them
+	ExcludePaths []string
+}
+
+// NewTracingConfig loads tracing configuration from environment variables
+func NewTracingConfig() TracingConfig {
+	cfg := TracingConfig{
+		Exporter:     utils.GetEnv("TRACING_EXPORTER", TracingExporterNone),
+		OTLPEndpoint: utils.GetEnv("TRACING_OTLP_ENDPOINT", ""),
+		SampleRatio:  utils.GetEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		ExcludePaths: utils.GetEnvSlice("TRACING_EXCLUDE_PATHS", []string{"/metrics", "/health"}),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid tracing configuration: %v", err))
+	}
+
+	return cfg
+}
+
+// Enabled returns true if spans are exported
+func (c *TracingConfig) Enabled() bool {
+	return c.Exporter != TracingExporterNone
+}
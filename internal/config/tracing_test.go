@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// clearAllTracingEnvVars clears all tracing-related environment variables.
+func clearAllTracingEnvVars(t *testing.T) {
+	t.Helper()
+	for _, v := range []string{"TRACING_EXPORTER", "TRACING_OTLP_ENDPOINT", "TRACING_SAMPLE_RATIO", "TRACING_EXCLUDE_PATHS"} {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+}
+
+// =============================================================================
+// NewTracingConfig Tests
+// =============================================================================
+
+func TestNewTracingConfig_UsesDefaults(t *testing.T) {
+	clearAllTracingEnvVars(t)
+
+	cfg := NewTracingConfig()
+
+	if cfg.Exporter != TracingExporterNone || cfg.Enabled() || cfg.OTLPEndpoint != "" || cfg.SampleRatio != 1 {
+		t.Errorf("NewTracingConfig() = %+v, want disabled with every trace sampled", cfg)
+	}
+	if len(cfg.ExcludePaths) != 2 || cfg.ExcludePaths[0] != "/metrics" || cfg.ExcludePaths[1] != "/health" {
+		t.Errorf("NewTracingConfig() ExcludePaths = %v, want [/metrics /health]", cfg.ExcludePaths)
+	}
+}
+
+func TestNewTracingConfig_LoadsOTLPFromEnv(t *testing.T) {
+	clearAllTracingEnvVars(t)
+	setEnvForTest(t, "TRACING_EXPORTER", "otlp")
+	setEnvForTest(t, "TRACING_OTLP_ENDPOINT", "http://otel-collector:4318")
+	setEnvForTest(t, "TRACING_SAMPLE_RATIO", "0.1")
+	setEnvForTest(t, "TRACING_EXCLUDE_PATHS", "/metrics")
+
+	cfg := NewTracingConfig()
+
+	if cfg.Exporter != TracingExporterOTLP || !cfg.Enabled() || cfg.OTLPEndpoint != "http://otel-collector:4318" || cfg.SampleRatio != 0.1 {
+		t.Errorf("NewTracingConfig() = %+v, want OTLP to the collector sampling 10%%", cfg)
+	}
+	if len(cfg.ExcludePaths) != 1 || cfg.ExcludePaths[0] != "/metrics" {
+		t.Errorf("NewTracingConfig() ExcludePaths = %v, want [/metrics]", cfg.ExcludePaths)
+	}
+}
+
+func TestNewTracingConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "unknown exporter", key: "TRACING_EXPORTER", value: "jaeger"},
+		{name: "invalid endpoint", key: "TRACING_OTLP_ENDPOINT", value: "not a url"},
+		{name: "ratio above one", key: "TRACING_SAMPLE_RATIO", value: "1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllTracingEnvVars(t)
+			setEnvForTest(t, tt.key, tt.value)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewTracingConfig() should panic")
+				}
+			}()
+			NewTracingConfig()
+		})
+	}
+}
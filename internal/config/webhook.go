@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// WebhookConfig holds configuration of the worker that sends webhook deliveries
+type WebhookConfig struct {
+	Timeout      time.Duration `validate:"gte=1s"`    // Of one delivery request
+	PollInterval time.Duration `validate:"gte=100ms"` // How often to check for due deliveries
+
+	// Failed deliveries are retried with exponential backoff up to MaxBackoff
+	// and marked dead after MaxAttempts
+	MaxAttempts int           `validate:"min=1,max=100"`
+	MaxBackoff  time.Duration `validate:"gte=1s"`
+}
+
+// NewWebhookConfig loads webhook configuration from environment variables
+func NewWebhookConfig() WebhookConfig {
+	cfg := WebhookConfig{
+		Timeout:      utils.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		PollInterval: utils.GetEnvDuration("WEBHOOK_POLL_INTERVAL", time.Second),
+		MaxAttempts:  utils.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		MaxBackoff:   utils.GetEnvDuration("WEBHOOK_MAX_BACKOFF", time.Hour),
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		panic(fmt.Sprintf("Invalid webhook configuration: %v", err))
+	}
+
+	return cfg
+}
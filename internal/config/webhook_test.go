@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// clearAllWebhookEnvVars clears all webhook-related environment variables.
+func clearAllWebhookEnvVars(t *testing.T) {
+	t.Helper()
+	vars := []string{"WEBHOOK_TIMEOUT", "WEBHOOK_POLL_INTERVAL", "WEBHOOK_MAX_ATTEMPTS", "WEBHOOK_MAX_BACKOFF"}
+	for _, v := range vars {
+		t.Setenv(v, "")
+		os.Unsetenv(v) //nolint:errcheck // test cleanup
+	}
+}
+
+// =============================================================================
+// NewWebhookConfig Tests
+// =============================================================================
+
+func TestNewWebhookConfig_UsesDefaults(t *testing.T) {
+	clearAllWebhookEnvVars(t)
+
+	cfg := NewWebhookConfig()
+
+	want := WebhookConfig{Timeout: 10 * time.Second, PollInterval: time.Second, MaxAttempts: 10, MaxBackoff: time.Hour}
+	if cfg != want {
+		t.Errorf("NewWebhookConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestNewWebhookConfig_ValidationPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{name: "no attempts", key: "WEBHOOK_MAX_ATTEMPTS", value: "0"},
+		{name: "timeout too short", key: "WEBHOOK_TIMEOUT", value: "10ms"},
+		{name: "backoff too short", key: "WEBHOOK_MAX_BACKOFF", value: "100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearAllWebhookEnvVars(t)
+			setEnvForTest(t, tt.key, tt.value)
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("NewWebhookConfig() should panic")
+				}
+			}()
+			NewWebhookConfig()
+		})
+	}
+}
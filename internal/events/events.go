@@ -0,0 +1,34 @@
+// Package events defines the domain events published for item changes
+// and the publishers that deliver them.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Item event types
+const (
+	ItemCreated = "ItemCreated"
+	ItemUpdated = "ItemUpdated" // Also sent when an item is restored from the trash
+	ItemDeleted = "ItemDeleted" // Sent when an item is moved to the trash
+)
+
+// Event is a published domain event. Delivery is at least once, so consumers
+// should deduplicate on ID; events of one aggregate may arrive out of order
+// after a retry, so consumers should compare the version in Data.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"` // The aggregate after the change
+}
+
+// Publisher delivers events to other services.
+// A nil error means the event was accepted and will not be sent again.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
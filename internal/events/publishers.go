@@ -0,0 +1,112 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LogPublisher writes events to the service log
+type LogPublisher struct{}
+
+// Publish logs the event
+func (LogPublisher) Publish(_ context.Context, event Event) error {
+	slog.Info("Domain event",
+		"id", event.ID,
+		"type", event.Type,
+		"aggregate_type", event.AggregateType,
+		"aggregate_id", event.AggregateID,
+		"data", string(event.Data),
+	)
+	return nil
+}
+
+// HTTPPublisher POSTs each event as JSON to a webhook URL.
+// Any 2xx response accepts the event.
+type HTTPPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPublisher returns a publisher for url that gives up on a request after timeout
+func NewHTTPPublisher(url string, timeout time.Duration) *HTTPPublisher {
+	return &HTTPPublisher{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// Publish sends the event to the webhook
+func (p *HTTPPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()                                       //nolint:errcheck // response body is drained and discarded
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Allow connection reuse
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiPublisher publishes every event to each of its publishers.
+// It fails if any of them fails, and a retry publishes to all of them again.
+type MultiPublisher []Publisher
+
+// Publish sends the event to all publishers
+func (m MultiPublisher) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MemoryPublisher keeps published events in memory, for tests
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+
+	// Fail, if set, is called before an event is recorded;
+	// a non-nil result is returned instead of publishing.
+	Fail func(Event) error
+}
+
+// Publish records the event unless Fail rejects it
+func (p *MemoryPublisher) Publish(_ context.Context, event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Fail != nil {
+		if err := p.Fail(event); err != nil {
+			return err
+		}
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns the published events in order
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Event(nil), p.events...)
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testEvent = Event{
+	ID:            7,
+	Type:          ItemUpdated,
+	AggregateType: "item",
+	AggregateID:   42,
+	OccurredAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	Data:          json.RawMessage(`{"id":42,"name":"Widget","version":2}`),
+}
+
+// =============================================================================
+// HTTPPublisher Tests
+// =============================================================================
+
+func TestHTTPPublisher_PostsEventAsJSON(t *testing.T) {
+	var got Event
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := NewHTTPPublisher(server.URL, time.Second).Publish(context.Background(), testEvent)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+	if got.ID != testEvent.ID || got.Type != testEvent.Type || got.AggregateID != testEvent.AggregateID ||
+		!got.OccurredAt.Equal(testEvent.OccurredAt) || string(got.Data) != string(testEvent.Data) {
+		t.Errorf("received %+v, want %+v", got, testEvent)
+	}
+}
+
+func TestHTTPPublisher_FailsOnNon2xxResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewHTTPPublisher(server.URL, time.Second).Publish(context.Background(), testEvent)
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Publish() error = %v, want status 503", err)
+	}
+}
+
+// =============================================================================
+// MemoryPublisher Tests
+// =============================================================================
+
+func TestMemoryPublisher_RecordsUnlessFailed(t *testing.T) {
+	p := &MemoryPublisher{Fail: func(e Event) error {
+		if e.ID == 2 {
+			return errors.New("rejected")
+		}
+		return nil
+	}}
+
+	first, second := testEvent, testEvent
+	second.ID = 2
+	if err := p.Publish(context.Background(), first); err != nil {
+		t.Errorf("Publish(1) error = %v", err)
+	}
+	if err := p.Publish(context.Background(), second); err == nil {
+		t.Error("Publish(2) should fail")
+	}
+
+	if got := p.Events(); len(got) != 1 || got[0].ID != 7 {
+		t.Errorf("Events() = %+v, want only event 7", got)
+	}
+}
+
+// =============================================================================
+// MultiPublisher Tests
+// =============================================================================
+
+func TestMultiPublisher_PublishesToAllAndJoinsErrors(t *testing.T) {
+	failing := &MemoryPublisher{Fail: func(Event) error { return errors.New("down") }}
+	first, last := &MemoryPublisher{}, &MemoryPublisher{}
+
+	err := MultiPublisher{first, failing, last}.Publish(context.Background(), testEvent)
+
+	if err == nil || err.Error() != "down" {
+		t.Errorf("Publish() error = %v, want down", err)
+	}
+	if len(first.Events()) != 1 || len(last.Events()) != 1 {
+		t.Error("every publisher should receive the event despite a failure")
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Creates an API key for a machine client. The key is returned only in this response.
+// @Description Requires the "admin" role.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param key body models.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} models.CreateAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		problem.Respond(c, problem.Invalid(problem.CodeValidationFailed, []problem.FieldError{
+			{Field: "expires_at", Rule: "future", Message: i18n.T(c.Request.Context(), "{0} must be in the future", "expires_at")},
+		}))
+		return
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to create API key")
+		return
+	}
+
+	apiKey := models.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    strings.Join(req.Scopes, " "),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := h.repo.CreateAPIKey(c.Request.Context(), &apiKey); err != nil {
+		LogAndRespondError(c, err, "Failed to create API key")
+		return
+	}
+	c.JSON(http.StatusCreated, models.CreateAPIKeyResponse{APIKey: apiKey, Key: key})
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Returns all API keys, including revoked ones. Keys themselves are never returned.
+// @Description Requires the "admin" role.
+// @Tags API Keys
+// @Produce json
+// @Success 200 {array} models.APIKey
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys [get]
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.repo.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve API keys")
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revokes an API key by ID; requests using it are rejected immediately.
+// @Description Requires the "admin" role.
+// @Tags API Keys
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	if err := h.repo.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		HandleRepositoryError(c, err, "API key not found", "Failed to revoke API key")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// ItemHistory godoc
+// @Summary Get item history
+// @Description Returns the audit events of an item, newest first, including after it was purged.
+// @Description Each event has the actor, request ID and a field-level diff. Requires the "admin" role.
+// @Tags Audit
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of events to skip"
+// @Success 200 {object} models.AuditPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/items/{id}/history [get]
+func (h *Handler) ItemHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	var query models.ItemHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	page, err := h.repo.ListAuditEvents(c.Request.Context(), repository.AuditListOptions{
+		EntityType: repository.AuditEntityItem,
+		EntityID:   id,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve item history")
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// ListAuditEvents godoc
+// @Summary List audit events
+// @Description Returns audit events of all entities, newest first. Requires the "admin" role.
+// @Tags Audit
+// @Produce json
+// @Param entity_type query string false "Entity type, e.g. item"
+// @Param entity_id query int false "Entity ID"
+// @Param action query string false "Action" Enums(create, update, delete, restore, purge)
+// @Param actor query string false "Actor (token subject, api-key:<id>, anonymous or system:purger)"
+// @Param request_id query string false "Request ID"
+// @Param since query string false "Only events at or after this RFC 3339 time"
+// @Param until query string false "Only events before this RFC 3339 time"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of events to skip"
+// @Success 200 {object} models.AuditPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/audit [get]
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	var query models.ListAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	page, err := h.repo.ListAuditEvents(c.Request.Context(), repository.AuditListOptions{
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		Action:     query.Action,
+		Actor:      query.Actor,
+		RequestID:  query.RequestID,
+		Since:      query.Since,
+		Until:      query.Until,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve audit events")
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// auditRepo records the options of the last ListAuditEvents call
+type auditRepo struct {
+	repository.Repository
+	opts  repository.AuditListOptions
+	calls int
+}
+
+func (r *auditRepo) ListAuditEvents(_ context.Context, opts repository.AuditListOptions) (*models.AuditPage, error) {
+	r.opts = opts
+	r.calls++
+	return &models.AuditPage{Events: []models.AuditEvent{}}, nil
+}
+
+// performAudit sends a GET request to the audit routes
+func performAudit(repo *auditRepo, target string) int {
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{repo: repo}
+	router := gin.New()
+	router.GET("/items/:id/history", h.ItemHistory)
+	router.GET("/audit", h.ListAuditEvents)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w.Code
+}
+
+// =============================================================================
+// Audit Tests
+// =============================================================================
+
+func TestItemHistory_FiltersByItem(t *testing.T) {
+	repo := &auditRepo{}
+
+	code := performAudit(repo, "/items/42/history?limit=5&offset=10")
+
+	want := repository.AuditListOptions{EntityType: repository.AuditEntityItem, EntityID: 42, Limit: 5, Offset: 10}
+	if code != http.StatusOK || repo.opts != want {
+		t.Errorf("status = %d, opts = %+v, want 200 and %+v", code, repo.opts, want)
+	}
+}
+
+func TestListAuditEvents_Filters(t *testing.T) {
+	repo := &auditRepo{}
+
+	code := performAudit(repo, "/audit?entity_type=item&action=update&actor=alice&request_id=req-1"+
+		"&since=2026-01-02T03:04:05Z&until=2026-02-01T00:00:00%2B02:00")
+
+	if code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	opts := repo.opts
+	if opts.EntityType != "item" || opts.Action != "update" || opts.Actor != "alice" || opts.RequestID != "req-1" {
+		t.Errorf("opts = %+v", opts)
+	}
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	until := time.Date(2026, 1, 31, 22, 0, 0, 0, time.UTC)
+	if opts.Since == nil || !opts.Since.Equal(since) || opts.Until == nil || !opts.Until.Equal(until) {
+		t.Errorf("since = %v, until = %v, want %v and %v", opts.Since, opts.Until, since, until)
+	}
+}
+
+func TestAuditHandlers_RejectInvalidQueries(t *testing.T) {
+	tests := []string{
+		"/items/abc/history",
+		"/items/1/history?limit=500",
+		"/audit?action=rename",
+		"/audit?since=yesterday",
+		"/audit?entity_id=-1",
+	}
+
+	for _, target := range tests {
+		t.Run(target, func(t *testing.T) {
+			repo := &auditRepo{}
+			if code := performAudit(repo, target); code != http.StatusBadRequest || repo.calls != 0 {
+				t.Errorf("status = %d, calls = %d, want 400 without a query", code, repo.calls)
+			}
+		})
+	}
+}
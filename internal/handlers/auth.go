@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// dummyPasswordHash is compared against when the user does not exist,
+// so unknown usernames take as long as wrong passwords. Its cost is auth.PasswordCost.
+var dummyPasswordHash = []byte("$2a$10$AlMG4JlGxZNTL/euFGZgyObjDu/y0k5R2quDWGysjQkAMLFb.EDP.")
+
+// IssueToken godoc
+// @Summary Obtain tokens
+// @Description Exchanges username and password for an access/refresh token pair
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginRequest true "User credentials"
+// @Success 200 {object} models.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/token [post]
+func (h *Handler) IssueToken(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	user, err := h.repo.GetUserByUsername(c.Request.Context(), req.Username)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			LogAndRespondError(c, err, "Failed to issue token")
+			return
+		}
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+		RespondError(c, problem.CodeInvalidCredentials, "Invalid username or password")
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		RespondError(c, problem.CodeInvalidCredentials, "Invalid username or password")
+		return
+	}
+
+	familyID, err := auth.RandomID()
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to issue token")
+		return
+	}
+
+	h.issueTokenPair(c, user, familyID, nil)
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access/refresh token pair.
+// @Description The presented refresh token is revoked; presenting it again revokes the whole token family.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body models.RefreshRequest true "Refresh token"
+// @Success 200 {object} models.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/refresh [post]
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	stored, err := h.repo.GetRefreshTokenByHash(c.Request.Context(), auth.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, problem.CodeInvalidToken, "Invalid refresh token")
+			return
+		}
+		LogAndRespondError(c, err, "Failed to refresh token")
+		return
+	}
+
+	if stored.RevokedAt != nil {
+		h.revokeReusedFamily(c, stored)
+		return
+	}
+	if !stored.IsActive(time.Now()) {
+		RespondError(c, problem.CodeTokenExpired, "Refresh token has expired")
+		return
+	}
+
+	// Reload the user so role changes apply on the next refresh
+	user, err := h.repo.GetUserByID(c.Request.Context(), stored.UserID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			LogAndRespondError(c, err, "Failed to refresh token")
+			return
+		}
+		// The user was deleted: the family can never be refreshed again
+		if err := h.repo.RevokeRefreshTokenFamily(c.Request.Context(), stored.FamilyID); err != nil {
+			LogAndRespondError(c, err, "Failed to refresh token")
+			return
+		}
+		RespondError(c, problem.CodeInvalidToken, "Invalid refresh token")
+		return
+	}
+
+	h.issueTokenPair(c, user, stored.FamilyID, stored)
+}
+
+// Logout godoc
+// @Summary Revoke tokens
+// @Description Revokes the refresh token and every token rotated from the same login
+// @Tags Auth
+// @Accept json
+// @Param token body models.RefreshRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	stored, err := h.repo.GetRefreshTokenByHash(c.Request.Context(), auth.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		// Unknown tokens are treated as already logged out
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		LogAndRespondError(c, err, "Failed to revoke token")
+		return
+	}
+
+	if err := h.repo.RevokeRefreshTokenFamily(c.Request.Context(), stored.FamilyID); err != nil {
+		LogAndRespondError(c, err, "Failed to revoke token")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// issueTokenPair signs an access token and stores a new refresh token in the family.
+// When current is set it is rotated (revoked) in the same transaction.
+func (h *Handler) issueTokenPair(c *gin.Context, user *models.User, familyID string, current *models.RefreshToken) {
+	accessToken, expiresAt, err := h.tokens.IssueAccessToken(strconv.FormatInt(user.ID, 10), user.RoleList())
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to issue token")
+		return
+	}
+
+	refreshToken, refreshHash, err := auth.NewRefreshToken()
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to issue token")
+		return
+	}
+
+	next := &models.RefreshToken{
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		TokenHash: refreshHash,
+		ExpiresAt: time.Now().Add(h.tokens.RefreshExpiry()),
+	}
+
+	ctx := c.Request.Context()
+	if current == nil {
+		err = h.repo.CreateRefreshToken(ctx, next)
+	} else {
+		err = h.repo.RotateRefreshToken(ctx, current, next)
+	}
+	if errors.Is(err, repository.ErrRefreshTokenReused) {
+		h.revokeReusedFamily(c, current)
+		return
+	}
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to issue token")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(expiresAt).Round(time.Second).Seconds()),
+	})
+}
+
+// revokeReusedFamily handles presentation of an already rotated refresh token.
+// The token may have been stolen, so every token in its family is revoked.
+func (h *Handler) revokeReusedFamily(c *gin.Context, token *models.RefreshToken) {
+	slog.WarnContext(c.Request.Context(), "Refresh token reuse detected, revoking family",
+		"family_id", token.FamilyID,
+		"user_id", token.UserID,
+	)
+	if err := h.repo.RevokeRefreshTokenFamily(c.Request.Context(), token.FamilyID); err != nil {
+		LogAndRespondError(c, err, "Failed to refresh token")
+		return
+	}
+	RespondError(c, problem.CodeInvalidToken, "Invalid refresh token")
+}
+
+// JWKS godoc
+// @Summary Public signing keys
+// @Description Returns the JSON Web Key Set used to verify tokens issued by this service
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} auth.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwks)
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// tokenRepo stubs user and refresh token persistence in memory
+type tokenRepo struct {
+	repository.Repository
+	users  map[string]*models.User
+	tokens []*models.RefreshToken
+}
+
+func newTokenRepo(t *testing.T) *tokenRepo {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	return &tokenRepo{users: map[string]*models.User{
+		"alice": {ID: 7, Username: "alice", PasswordHash: string(hash), Roles: "editor"},
+	}}
+}
+
+func (r *tokenRepo) GetUserByUsername(_ context.Context, username string) (*models.User, error) {
+	if user, ok := r.users[username]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *tokenRepo) GetUserByID(_ context.Context, id int64) (*models.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *tokenRepo) CreateRefreshToken(_ context.Context, token *models.RefreshToken) error {
+	token.ID = int64(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *tokenRepo) GetRefreshTokenByHash(_ context.Context, hash string) (*models.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			stored := *token
+			return &stored, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *tokenRepo) RotateRefreshToken(ctx context.Context, current, next *models.RefreshToken) error {
+	for _, token := range r.tokens {
+		if token.ID == current.ID {
+			if token.RevokedAt != nil {
+				return repository.ErrRefreshTokenReused
+			}
+			now := time.Now()
+			token.RevokedAt = &now
+		}
+	}
+	return r.CreateRefreshToken(ctx, next)
+}
+
+func (r *tokenRepo) RevokeRefreshTokenFamily(_ context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// active counts the refresh tokens that are not revoked
+func (r *tokenRepo) active() int {
+	n := 0
+	for _, token := range r.tokens {
+		if token.RevokedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// newTokenHandler returns a handler issuing HS256 tokens from repo
+func newTokenHandler(repo *tokenRepo) *Handler {
+	return &Handler{repo: repo, tokens: auth.NewTokenIssuer(&config.JWTConfig{
+		Secret:        "test-secret-that-is-at-least-32-bytes",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: time.Hour,
+		RolesClaim:    "roles",
+		ScopesClaim:   "scope",
+	})}
+}
+
+// performAuth posts body to an auth endpoint and decodes the token response
+// on 200 or the problem code of an error
+func performAuth(t *testing.T, h *Handler, path, body string) (int, models.TokenResponse, problem.Code) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/auth/token", h.IssueToken)
+	router.POST("/auth/refresh", h.RefreshToken)
+	router.POST("/auth/logout", h.Logout)
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp models.TokenResponse
+	var p ErrorResponse
+	switch w.Code {
+	case http.StatusNoContent:
+		return w.Code, resp, ""
+	case http.StatusOK:
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+		}
+	default:
+		if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+			t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+		}
+	}
+	return w.Code, resp, p.Code
+}
+
+// login issues a token pair for alice
+func login(t *testing.T, h *Handler) models.TokenResponse {
+	t.Helper()
+	code, resp, _ := performAuth(t, h, "/auth/token", `{"username": "alice", "password": "secret-password"}`)
+	if code != http.StatusOK {
+		t.Fatalf("login status = %d, want 200", code)
+	}
+	return resp
+}
+
+// refreshBody is the request body presenting a refresh token
+func refreshBody(token string) string {
+	return `{"refresh_token": "` + token + `"}`
+}
+
+// =============================================================================
+// Token Endpoint Tests
+// =============================================================================
+
+func TestIssueToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "valid credentials", body: `{"username": "alice", "password": "secret-password"}`, wantCode: http.StatusOK},
+		{name: "wrong password", body: `{"username": "alice", "password": "wrong-password"}`, wantCode: http.StatusUnauthorized},
+		{name: "unknown user", body: `{"username": "bob", "password": "secret-password"}`, wantCode: http.StatusUnauthorized},
+		{name: "missing password", body: `{"username": "alice"}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTokenRepo(t)
+
+			code, resp, _ := performAuth(t, newTokenHandler(repo), "/auth/token", tt.body)
+
+			if code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", code, tt.wantCode)
+			}
+			if code != http.StatusOK {
+				if repo.active() != 0 {
+					t.Errorf("active tokens = %d, want none", repo.active())
+				}
+				return
+			}
+			if resp.AccessToken == "" || resp.RefreshToken == "" || resp.TokenType != "Bearer" || resp.ExpiresIn != 900 {
+				t.Errorf("response = %+v", resp)
+			}
+			if len(repo.tokens) != 1 || repo.tokens[0].UserID != 7 ||
+				repo.tokens[0].TokenHash != auth.HashRefreshToken(resp.RefreshToken) {
+				t.Errorf("stored tokens = %+v, want one hashed token of user 7", repo.tokens)
+			}
+		})
+	}
+}
+
+func TestDummyPasswordHash_UsesPasswordCost(t *testing.T) {
+	// Unknown usernames must take as long as wrong passwords of provisioned users
+	if cost, err := bcrypt.Cost(dummyPasswordHash); err != nil || cost != auth.PasswordCost {
+		t.Errorf("Cost() = %d, %v, want auth.PasswordCost %d", cost, err, auth.PasswordCost)
+	}
+}
+
+func TestRefreshToken_RotatesWithinFamily(t *testing.T) {
+	repo := newTokenRepo(t)
+	h := newTokenHandler(repo)
+	first := login(t, h)
+
+	code, second, _ := performAuth(t, h, "/auth/refresh", refreshBody(first.RefreshToken))
+
+	if code != http.StatusOK || second.RefreshToken == "" || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("status = %d, response = %+v, want a new token pair", code, second)
+	}
+	if len(repo.tokens) != 2 || repo.tokens[0].RevokedAt == nil || repo.tokens[1].RevokedAt != nil ||
+		repo.tokens[0].FamilyID != repo.tokens[1].FamilyID {
+		t.Errorf("tokens = %+v, want the first revoked and its successor active in the same family", repo.tokens)
+	}
+}
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	repo := newTokenRepo(t)
+	h := newTokenHandler(repo)
+	first := login(t, h)
+	if code, _, _ := performAuth(t, h, "/auth/refresh", refreshBody(first.RefreshToken)); code != http.StatusOK {
+		t.Fatalf("rotation status = %d, want 200", code)
+	}
+
+	// Presenting the rotated token again, e.g. by an attacker who stole it
+	code, _, problemCode := performAuth(t, h, "/auth/refresh", refreshBody(first.RefreshToken))
+
+	if code != http.StatusUnauthorized || problemCode != problem.CodeInvalidToken {
+		t.Errorf("status = %d, code = %q, want 401 invalid_token", code, problemCode)
+	}
+	if repo.active() != 0 {
+		t.Errorf("active tokens = %d, want the whole family revoked", repo.active())
+	}
+}
+
+func TestRefreshToken_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		expired  bool
+		token    string
+		wantCode problem.Code
+	}{
+		{name: "expired", expired: true, wantCode: problem.CodeTokenExpired},
+		{name: "unknown", token: "not-a-refresh-token", wantCode: problem.CodeInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTokenRepo(t)
+			h := newTokenHandler(repo)
+			token := login(t, h).RefreshToken
+			if tt.expired {
+				repo.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+			}
+			if tt.token != "" {
+				token = tt.token
+			}
+
+			code, _, problemCode := performAuth(t, h, "/auth/refresh", refreshBody(token))
+
+			if code != http.StatusUnauthorized || problemCode != tt.wantCode {
+				t.Errorf("status = %d, code = %q, want 401 %s", code, problemCode, tt.wantCode)
+			}
+			if len(repo.tokens) != 1 {
+				t.Errorf("tokens = %d, want no new token", len(repo.tokens))
+			}
+		})
+	}
+}
+
+func TestLogout_RevokesFamily(t *testing.T) {
+	repo := newTokenRepo(t)
+	h := newTokenHandler(repo)
+	first := login(t, h)
+	_, second, _ := performAuth(t, h, "/auth/refresh", refreshBody(first.RefreshToken))
+
+	if code, _, _ := performAuth(t, h, "/auth/logout", refreshBody(second.RefreshToken)); code != http.StatusNoContent {
+		t.Fatalf("logout status = %d, want 204", code)
+	}
+	if repo.active() != 0 {
+		t.Errorf("active tokens = %d, want none after logout", repo.active())
+	}
+	if code, _, _ := performAuth(t, h, "/auth/refresh", refreshBody(second.RefreshToken)); code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout status = %d, want 401", code)
+	}
+	if code, _, _ := performAuth(t, h, "/auth/logout", refreshBody("unknown-token")); code != http.StatusNoContent {
+		t.Errorf("logout of unknown token status = %d, want 204", code)
+	}
+}
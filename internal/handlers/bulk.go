@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// bulkPlan holds the indexes of valid bulk operations, grouped by kind
+type bulkPlan struct {
+	creates []int
+	updates []int
+	deletes []int
+}
+
+// bulkOpError is the failure of one operation, aborting a transactional bulk request
+type bulkOpError struct {
+	index int
+	err   error
+}
+
+func (e *bulkOpError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.index, e.err)
+}
+
+func (e *bulkOpError) Unwrap() error {
+	return e.err
+}
+
+// BulkItems godoc
+// @Summary Create, update and delete items in bulk
+// @Description Applies up to 1000 operations. Creates run first, then updates, then deletes;
+// @Description results are returned in request order. By default all operations run in one
+// @Description transaction: on failure nothing is applied, the response has the status of the
+// @Description failed operation and all others report 424. With best_effort=true operations are
+// @Description applied independently and the response is 207 with a status per operation.
+// @Description Deletes require the "admin" role. Item IDs must be unique within a request.
+// @Tags Items
+// @Accept json
+// @Produce json
+// @Param best_effort query bool false "Apply operations independently instead of in one transaction"
+// @Param request body models.BulkItemsRequest true "Operations"
+// @Success 200 {object} models.BulkItemsResponse
+// @Success 207 {object} models.BulkItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/items/bulk [post]
+func (h *Handler) BulkItems(c *gin.Context) {
+	var query models.BulkItemsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	var req models.BulkItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	ops := req.Operations
+	results := make([]models.BulkItemResult, len(ops))
+	plan, valid := h.planBulk(c, ops, results)
+
+	if query.BestEffort {
+		h.applyBulkBestEffort(c.Request.Context(), ops, plan, results)
+		c.JSON(http.StatusMultiStatus, models.BulkItemsResponse{Results: results})
+		return
+	}
+
+	if !valid {
+		respondBulkRollback(c, results)
+		return
+	}
+
+	err := h.applyBulkAtomic(c.Request.Context(), ops, plan, results)
+	var opErr *bulkOpError
+	if errors.As(err, &opErr) {
+		results[opErr.index] = bulkErrorResult(c.Request.Context(), opErr.index, opErr.err)
+		respondBulkRollback(c, results)
+		return
+	}
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to apply bulk operations")
+		return
+	}
+	c.JSON(http.StatusOK, models.BulkItemsResponse{Results: results})
+}
+
+// planBulk validates each operation, recording failures in results,
+// and groups the valid ones. valid is false if any operation failed.
+func (h *Handler) planBulk(c *gin.Context, ops []models.BulkItemOperation, results []models.BulkItemResult) (plan bulkPlan, valid bool) {
+	// Without claims, auth is not configured and deletes are open like DELETE /items/:id
+	ctx := c.Request.Context()
+	claims, authenticated := auth.GetClaims(c)
+	canDelete := !authenticated || claims.HasRole(auth.RoleAdmin)
+
+	seen := make(map[int64]bool)
+	valid = true
+	for i := range ops {
+		op := &ops[i]
+		var status int
+		var message string
+
+		err := binding.Validator.ValidateStruct(op)
+		switch {
+		case err != nil:
+			status, message = http.StatusBadRequest, validationMessage(ctx, err)
+		case op.Op == "delete" && !canDelete:
+			status, message = http.StatusForbidden, i18n.T(ctx, "Requires one of roles: {0}", auth.RoleAdmin)
+		case op.Op != "create" && op.Version == nil && h.requireIfMatch:
+			status, message = http.StatusPreconditionRequired, i18n.T(ctx, "Version is required")
+		case op.Op != "create" && seen[op.ID]:
+			status, message = http.StatusBadRequest, i18n.T(ctx, "Duplicate item ID {0}", strconv.FormatInt(op.ID, 10))
+		}
+		if status != 0 {
+			results[i] = models.BulkItemResult{Status: status, Error: message}
+			valid = false
+			continue
+		}
+
+		switch op.Op {
+		case "create":
+			plan.creates = append(plan.creates, i)
+		case "update":
+			seen[op.ID] = true
+			plan.updates = append(plan.updates, i)
+		case "delete":
+			seen[op.ID] = true
+			plan.deletes = append(plan.deletes, i)
+		}
+	}
+	return plan, valid
+}
+
+// applyBulkAtomic applies all planned operations in one transaction.
+// Results are only filled in once every operation has succeeded; the failure
+// of a single operation is returned as a *bulkOpError.
+func (h *Handler) applyBulkAtomic(ctx context.Context, ops []models.BulkItemOperation, plan bulkPlan, results []models.BulkItemResult) error {
+	creates := bulkCreates(ops, plan.creates)
+	updates := bulkUpdates(ops, plan.updates)
+
+	err := h.repo.Transaction(ctx, func(tx repository.Repository) error {
+		if err := tx.CreateItems(ctx, creates); err != nil {
+			return err
+		}
+		for j, i := range plan.updates {
+			if err := tx.UpdateItem(ctx, updates[j], bulkVersions(ops[i])); err != nil {
+				return &bulkOpError{index: i, err: err}
+			}
+		}
+		outcomes, err := tx.DeleteItems(ctx, bulkDeletes(ops, plan.deletes))
+		if err != nil {
+			return err
+		}
+		for j, err := range outcomes {
+			if err != nil {
+				return &bulkOpError{index: plan.deletes[j], err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for j, i := range plan.creates {
+		results[i] = models.BulkItemResult{Status: http.StatusCreated, Item: creates[j]}
+	}
+	for j, i := range plan.updates {
+		results[i] = models.BulkItemResult{Status: http.StatusOK, Item: updates[j]}
+	}
+	for _, i := range plan.deletes {
+		results[i] = models.BulkItemResult{Status: http.StatusNoContent}
+	}
+	return nil
+}
+
+// applyBulkBestEffort applies planned operations independently and records each outcome
+func (h *Handler) applyBulkBestEffort(ctx context.Context, ops []models.BulkItemOperation, plan bulkPlan, results []models.BulkItemResult) {
+	creates := bulkCreates(ops, plan.creates)
+	if err := h.repo.CreateItems(ctx, creates); err != nil {
+		// The batch is all or nothing; retry one by one so only failing items report errors
+		for j, i := range plan.creates {
+			if err := h.repo.CreateItem(ctx, creates[j]); err != nil {
+				results[i] = bulkErrorResult(ctx, i, err)
+				continue
+			}
+			results[i] = models.BulkItemResult{Status: http.StatusCreated, Item: creates[j]}
+		}
+	} else {
+		for j, i := range plan.creates {
+			results[i] = models.BulkItemResult{Status: http.StatusCreated, Item: creates[j]}
+		}
+	}
+
+	updates := bulkUpdates(ops, plan.updates)
+	for j, i := range plan.updates {
+		if err := h.repo.UpdateItem(ctx, updates[j], bulkVersions(ops[i])); err != nil {
+			results[i] = bulkErrorResult(ctx, i, err)
+			continue
+		}
+		results[i] = models.BulkItemResult{Status: http.StatusOK, Item: updates[j]}
+	}
+
+	outcomes, err := h.repo.DeleteItems(ctx, bulkDeletes(ops, plan.deletes))
+	for j, i := range plan.deletes {
+		switch {
+		case err != nil:
+			results[i] = bulkErrorResult(ctx, i, err)
+		case outcomes[j] != nil:
+			results[i] = bulkErrorResult(ctx, i, outcomes[j])
+		default:
+			results[i] = models.BulkItemResult{Status: http.StatusNoContent}
+		}
+	}
+}
+
+// respondBulkRollback responds to a rolled back bulk request with the status of the
+// first failed operation. Operations without a result are reported as not applied.
+func respondBulkRollback(c *gin.Context, results []models.BulkItemResult) {
+	status := 0
+	for i := range results {
+		if results[i].Status == 0 {
+			results[i] = models.BulkItemResult{
+				Status: http.StatusFailedDependency,
+				Error:  i18n.T(c.Request.Context(), "Not applied because another operation failed"),
+			}
+		} else if status == 0 {
+			status = results[i].Status
+		}
+	}
+	c.JSON(status, models.BulkItemsResponse{Results: results})
+}
+
+// bulkErrorResult converts the repository error of one operation into its result,
+// using the same statuses as HandleRepositoryError
+func bulkErrorResult(ctx context.Context, index int, err error) models.BulkItemResult {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, repository.ErrNotFound):
+		return models.BulkItemResult{Status: http.StatusNotFound, Error: i18n.T(ctx, "Item not found")}
+	case errors.Is(err, repository.ErrVersionMismatch):
+		return models.BulkItemResult{Status: http.StatusPreconditionFailed, Error: i18n.T(ctx, "Version does not match the current version")}
+	default:
+		slog.ErrorContext(ctx, "Bulk operation error", "index", index, "error", err.Error())
+		return models.BulkItemResult{Status: http.StatusInternalServerError, Error: i18n.T(ctx, "Failed to apply operation")}
+	}
+}
+
+// bulkCreates builds the items to create for the given operations
+func bulkCreates(ops []models.BulkItemOperation, indexes []int) []*models.Item {
+	items := make([]*models.Item, len(indexes))
+	for j, i := range indexes {
+		items[j] = &models.Item{Name: ops[i].Name, Description: ops[i].Description}
+	}
+	return items
+}
+
+// bulkUpdates builds the items to update for the given operations
+func bulkUpdates(ops []models.BulkItemOperation, indexes []int) []*models.Item {
+	items := make([]*models.Item, len(indexes))
+	for j, i := range indexes {
+		items[j] = &models.Item{ID: ops[i].ID, Name: ops[i].Name, Description: ops[i].Description}
+	}
+	return items
+}
+
+// bulkDeletes builds the deletes for the given operations
+func bulkDeletes(ops []models.BulkItemOperation, indexes []int) []repository.ItemDelete {
+	deletes := make([]repository.ItemDelete, len(indexes))
+	for j, i := range indexes {
+		deletes[j] = repository.ItemDelete{ID: ops[i].ID, Version: ops[i].Version}
+	}
+	return deletes
+}
+
+// bulkVersions returns the If-Match style versions of an operation (nil for unconditional)
+func bulkVersions(op models.BulkItemOperation) []int64 {
+	if op.Version == nil {
+		return nil
+	}
+	return []int64{*op.Version}
+}
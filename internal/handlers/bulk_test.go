@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// bulkRepo stubs the repository methods used by BulkItems.
+// Items with IDs in missing do not exist; creates named "bad" fail.
+type bulkRepo struct {
+	repository.Repository
+	missing     map[int64]bool
+	batchFailed bool
+	nextID      int64
+	committed   bool
+}
+
+func (r *bulkRepo) Transaction(_ context.Context, fn func(tx repository.Repository) error) error {
+	err := fn(r)
+	r.committed = err == nil
+	return err
+}
+
+func (r *bulkRepo) CreateItems(ctx context.Context, items []*models.Item) error {
+	for _, item := range items {
+		if item.Name == "bad" {
+			r.batchFailed = true
+			return errors.New("insert failed")
+		}
+	}
+	for _, item := range items {
+		if err := r.CreateItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *bulkRepo) CreateItem(_ context.Context, item *models.Item) error {
+	if item.Name == "bad" {
+		return errors.New("insert failed")
+	}
+	r.nextID++
+	item.ID = r.nextID
+	item.Version = 1
+	return nil
+}
+
+func (r *bulkRepo) UpdateItem(_ context.Context, item *models.Item, _ []int64) error {
+	if r.missing[item.ID] {
+		return gorm.ErrRecordNotFound
+	}
+	item.Version = 2
+	return nil
+}
+
+func (r *bulkRepo) DeleteItems(_ context.Context, deletes []repository.ItemDelete) ([]error, error) {
+	outcomes := make([]error, len(deletes))
+	for i, d := range deletes {
+		if r.missing[d.ID] {
+			outcomes[i] = gorm.ErrRecordNotFound
+		} else if d.Version != nil && *d.Version != 1 {
+			outcomes[i] = repository.ErrVersionMismatch
+		}
+	}
+	return outcomes, nil
+}
+
+// performBulk posts a bulk request and decodes the result statuses
+func performBulk(t *testing.T, h *Handler, query, body string) (int, []int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/items/bulk", h.BulkItems)
+
+	req := httptest.NewRequest(http.MethodPost, "/items/bulk"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp models.BulkItemsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	statuses := make([]int, len(resp.Results))
+	for i, result := range resp.Results {
+		statuses[i] = result.Status
+	}
+	return w.Code, statuses
+}
+
+// assertStatuses compares per-operation statuses
+func assertStatuses(t *testing.T, got, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("statuses = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("statuses = %v, want %v", got, want)
+			return
+		}
+	}
+}
+
+const bulkMixedBody = `{"operations": [
+	{"op": "create", "name": "New"},
+	{"op": "update", "id": 7, "name": "Renamed"},
+	{"op": "delete", "id": 8, "version": 1}
+]}`
+
+// =============================================================================
+// BulkItems Tests
+// =============================================================================
+
+func TestBulkItems_TransactionAppliesAll(t *testing.T) {
+	repo := &bulkRepo{}
+
+	code, statuses := performBulk(t, &Handler{repo: repo}, "", bulkMixedBody)
+
+	if code != http.StatusOK || !repo.committed {
+		t.Errorf("status = %d, committed = %v, want 200 and committed", code, repo.committed)
+	}
+	assertStatuses(t, statuses, []int{http.StatusCreated, http.StatusOK, http.StatusNoContent})
+}
+
+func TestBulkItems_TransactionRollsBackOnFailure(t *testing.T) {
+	repo := &bulkRepo{missing: map[int64]bool{7: true}}
+
+	code, statuses := performBulk(t, &Handler{repo: repo}, "", bulkMixedBody)
+
+	if code != http.StatusNotFound || repo.committed {
+		t.Errorf("status = %d, committed = %v, want 404 and rolled back", code, repo.committed)
+	}
+	assertStatuses(t, statuses, []int{http.StatusFailedDependency, http.StatusNotFound, http.StatusFailedDependency})
+}
+
+func TestBulkItems_TransactionRejectsInvalidOperations(t *testing.T) {
+	repo := &bulkRepo{}
+	body := `{"operations": [
+		{"op": "create", "name": "Fine"},
+		{"op": "create", "id": 3, "name": "Has ID"},
+		{"op": "update", "id": 4},
+		{"op": "delete", "id": 5},
+		{"op": "delete", "id": 5}
+	]}`
+
+	code, statuses := performBulk(t, &Handler{repo: repo}, "", body)
+
+	if code != http.StatusBadRequest || repo.nextID != 0 {
+		t.Errorf("status = %d, created = %d, want 400 without writes", code, repo.nextID)
+	}
+	assertStatuses(t, statuses, []int{
+		http.StatusFailedDependency,
+		http.StatusBadRequest,
+		http.StatusBadRequest,
+		http.StatusFailedDependency,
+		http.StatusBadRequest,
+	})
+}
+
+func TestBulkItems_BestEffortReportsEachOperation(t *testing.T) {
+	repo := &bulkRepo{missing: map[int64]bool{9: true}}
+	body := `{"operations": [
+		{"op": "create", "name": "Good"},
+		{"op": "create", "name": "bad"},
+		{"op": "update", "id": 9, "name": "Gone"},
+		{"op": "delete", "id": 8, "version": 4},
+		{"op": "delete", "id": 10},
+		{"op": "bogus"}
+	]}`
+
+	code, statuses := performBulk(t, &Handler{repo: repo}, "?best_effort=true", body)
+
+	if code != http.StatusMultiStatus {
+		t.Errorf("status = %d, want %d", code, http.StatusMultiStatus)
+	}
+	if !repo.batchFailed {
+		t.Error("creates should be attempted as a batch first")
+	}
+	assertStatuses(t, statuses, []int{
+		http.StatusCreated,
+		http.StatusInternalServerError,
+		http.StatusNotFound,
+		http.StatusPreconditionFailed,
+		http.StatusNoContent,
+		http.StatusBadRequest,
+	})
+}
+
+func TestBulkItems_RequiresVersionWhenIfMatchRequired(t *testing.T) {
+	repo := &bulkRepo{}
+
+	code, statuses := performBulk(t, &Handler{repo: repo, requireIfMatch: true}, "?best_effort=true", bulkMixedBody)
+
+	if code != http.StatusMultiStatus {
+		t.Errorf("status = %d, want %d", code, http.StatusMultiStatus)
+	}
+	assertStatuses(t, statuses, []int{http.StatusCreated, http.StatusPreconditionRequired, http.StatusNoContent})
+}
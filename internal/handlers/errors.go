@@ -1,42 +1,88 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"log/slog"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
 )
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
+// ErrorResponse is the RFC 9457 problem details body (application/problem+json)
+// of every error response. RequestID matches the X-Request-ID header and the
+// request_id of the request's log records.
+type ErrorResponse = problem.Details
+
+// RespondError sends a problem of code without logging (for expected errors like validation).
+// message is an i18n key, translated with params into the request's locale for the detail.
+func RespondError(c *gin.Context, code problem.Code, message string, params ...string) {
+	problem.Respond(c, problem.New(code, i18n.T(c.Request.Context(), message, params...)))
 }
 
-// RespondError sends an error response without logging (for expected errors like validation)
-func RespondError(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, ErrorResponse{Error: message})
+// RespondBindingError sends the problem of a request body or query that failed to bind,
+// listing the invalid fields of validation failures
+func RespondBindingError(c *gin.Context, err error) {
+	problem.Respond(c, problem.Binding(c.Request.Context(), err))
 }
 
-// LogAndRespondError logs the error and sends an error response
-func LogAndRespondError(c *gin.Context, statusCode int, err error, userMessage string) {
-	slog.Error("Request error",
+// LogAndRespondError logs an unexpected error and sends an internal_error problem
+// with userMessage, an i18n key, as its detail
+func LogAndRespondError(c *gin.Context, err error, userMessage string) {
+	p := problem.New(problem.CodeInternal, i18n.T(c.Request.Context(), userMessage))
+	slog.ErrorContext(c.Request.Context(), "Request error",
 		"method", c.Request.Method,
 		"path", c.Request.URL.Path,
-		"status", statusCode,
+		"status", p.Status,
 		"error", err.Error(),
 	)
-	c.JSON(statusCode, ErrorResponse{Error: userMessage})
+	problem.Respond(c, p)
+}
+
+// messageError is an error for clients, with an i18n message key and its parameters
+type messageError struct {
+	message string
+	params  []string
+}
+
+func newMessageError(message string, params ...string) *messageError {
+	return &messageError{message: message, params: params}
+}
+
+// Error returns the message in English
+func (e *messageError) Error() string {
+	return i18n.T(context.Background(), e.message, e.params...)
+}
+
+// validationMessage describes why a value failed validation in the locale of ctx,
+// naming fields as clients know them instead of exposing validator internals
+func validationMessage(ctx context.Context, err error) string {
+	if fields := problem.FieldErrors(ctx, err); len(fields) > 0 {
+		return problem.Message(fields)
+	}
+	var msgErr *messageError
+	if errors.As(err, &msgErr) {
+		return i18n.T(ctx, msgErr.message, msgErr.params...)
+	}
+	return i18n.T(ctx, err.Error())
 }
 
 // HandleRepositoryError handles repository errors with appropriate responses
-// - Returns 404 for gorm.ErrRecordNotFound
-// - Returns 500 and logs for other errors
+// - Returns 404 not_found for gorm.ErrRecordNotFound and repository.ErrNotFound
+// - Returns 412 version_mismatch for repository.ErrVersionMismatch
+// - Returns 500 internal_error and logs for other errors
 func HandleRepositoryError(c *gin.Context, err error, notFoundMsg, internalMsg string) {
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		RespondError(c, http.StatusNotFound, notFoundMsg)
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, repository.ErrNotFound) {
+		RespondError(c, problem.CodeNotFound, notFoundMsg)
+		return
+	}
+	if errors.Is(err, repository.ErrVersionMismatch) {
+		RespondError(c, problem.CodeVersionMismatch, "If-Match does not match the current version")
 		return
 	}
-	LogAndRespondError(c, http.StatusInternalServerError, err, internalMsg)
+	LogAndRespondError(c, err, internalMsg)
 }
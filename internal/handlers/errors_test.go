@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/telemetry"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// performProblem serves a request to handler at POST /items and decodes the problem it answers
+func performProblem(t *testing.T, handler gin.HandlerFunc, body string) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(requestid.Middleware())
+	router.POST("/items", handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(requestid.Header, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Type"); got != problem.ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, problem.ContentType)
+	}
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	return w, resp
+}
+
+// =============================================================================
+// Error Response Tests
+// =============================================================================
+
+func TestLogAndRespondError_CorrelatesResponseAndLog(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(telemetry.NewLogHandler(slog.NewJSONHandler(&logs, nil))))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	w, resp := performProblem(t, func(c *gin.Context) {
+		LogAndRespondError(c, errors.New("connection reset"), "Failed to create item")
+	}, "")
+
+	want := ErrorResponse{
+		Type:      problem.TypeBaseURI + "internal_error",
+		Title:     "Internal server error",
+		Status:    http.StatusInternalServerError,
+		Detail:    "Failed to create item",
+		Instance:  "/items",
+		Code:      problem.CodeInternal,
+		RequestID: "req-1",
+	}
+	if w.Code != http.StatusInternalServerError || resp.Type != want.Type || resp.Title != want.Title ||
+		resp.Status != want.Status || resp.Detail != want.Detail || resp.Instance != want.Instance ||
+		resp.Code != want.Code || resp.RequestID != want.RequestID {
+		t.Errorf("response = %d %+v, want %+v", w.Code, resp, want)
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log record %s: %v", logs.String(), err)
+	}
+	if record["request_id"] != "req-1" || record["error"] != "connection reset" {
+		t.Errorf("log record = %v, want request_id req-1 and the error", record)
+	}
+}
+
+func TestRespondBindingError_ListsInvalidFields(t *testing.T) {
+	bind := func(c *gin.Context) {
+		var req models.CreateWebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			RespondBindingError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   problem.Code
+		wantErrors []problem.FieldError
+	}{
+		{
+			name:     "missing field",
+			body:     `{}`,
+			wantCode: problem.CodeValidationFailed,
+			wantErrors: []problem.FieldError{
+				{Field: "url", Rule: "required", Message: "url is required"},
+				{Field: "event_types", Rule: "required", Message: "event_types is required"},
+			},
+		},
+		{
+			name:     "rules and list elements",
+			body:     `{"url": "ftp://example.com", "event_types": ["ItemMoved"], "secret": "short"}`,
+			wantCode: problem.CodeValidationFailed,
+			wantErrors: []problem.FieldError{
+				{Field: "url", Rule: "http_url", Message: "url must be a valid URL"},
+				{Field: "event_types[0]", Rule: "oneof", Message: "event_types[0] must be one of: ItemCreated, ItemUpdated, ItemDeleted"},
+				{Field: "secret", Rule: "min", Message: "secret must be at least 16 characters"},
+			},
+		},
+		{
+			name:     "wrong type",
+			body:     `{"url": "https://example.com", "event_types": "ItemCreated"}`,
+			wantCode: problem.CodeValidationFailed,
+			wantErrors: []problem.FieldError{
+				{Field: "event_types", Rule: "type", Message: "event_types must be an array"},
+			},
+		},
+		{
+			name:     "malformed JSON",
+			body:     `{"url": `,
+			wantCode: problem.CodeInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, resp := performProblem(t, bind, tt.body)
+
+			if w.Code != http.StatusBadRequest || resp.Code != tt.wantCode {
+				t.Fatalf("response = %d %+v, want 400 %s", w.Code, resp, tt.wantCode)
+			}
+			if len(resp.Errors) != len(tt.wantErrors) {
+				t.Fatalf("errors = %+v, want %+v", resp.Errors, tt.wantErrors)
+			}
+			for i, want := range tt.wantErrors {
+				if resp.Errors[i] != want {
+					t.Errorf("errors[%d] = %+v, want %+v", i, resp.Errors[i], want)
+				}
+			}
+			if strings.Contains(resp.Detail, "Key:") || strings.Contains(resp.Detail, "json:") {
+				t.Errorf("detail = %q, should not expose decoder or validator text", resp.Detail)
+			}
+		})
+	}
+}
+
+func TestRespondError_OmitsRequestIDOutsideMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/items/:id", func(c *gin.Context) {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/abc", nil))
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	if _, ok := body["request_id"]; ok || body["code"] != "invalid_id" || body["instance"] != "/items/abc" {
+		t.Errorf("body = %s, want invalid_id problem without request_id", w.Body.String())
+	}
+}
+
+func TestRespondBindingError_LocalizesByAcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(i18n.Middleware())
+	router.POST("/webhooks", func(c *gin.Context) {
+		var req models.CreateWebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			RespondBindingError(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks",
+		strings.NewReader(`{"url": "https://example.com", "event_types": ["ItemCreated"], "secret": "short"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	want := problem.FieldError{Field: "secret", Rule: "min", Message: "secret muss mindestens 16 Zeichen sein"}
+	if w.Header().Get("Content-Language") != "de" || resp.Code != problem.CodeValidationFailed ||
+		resp.Title != "Validierung fehlgeschlagen" || len(resp.Errors) != 1 || resp.Errors[0] != want {
+		t.Errorf("response = %s %+v, want German validation_failed problem with %+v",
+			w.Header().Get("Content-Language"), resp, want)
+	}
+}
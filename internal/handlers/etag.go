@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// versionETag returns the strong entity tag for a resource version
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setETag sets the ETag response header for a resource version
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", versionETag(version))
+}
+
+// ifMatchVersions parses the If-Match header into the versions it accepts.
+// present is false without the header; versions is nil for "*" (any version)
+// and empty when no listed tag can match (weak or foreign tags).
+func ifMatchVersions(c *gin.Context) (versions []int64, present bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil, false
+	}
+	if header == "*" {
+		return nil, true
+	}
+
+	versions = []int64{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		// If-Match uses strong comparison, so weak tags never match
+		if !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) || len(tag) < 2 {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, true
+}
+
+// writePreconditions evaluates If-Match for a conditional write.
+// Returns the versions to pass to the repository (nil for unconditional writes) and
+// false after responding 428 (header required but missing) or 412 (no tag can match).
+func (h *Handler) writePreconditions(c *gin.Context) ([]int64, bool) {
+	versions, present := ifMatchVersions(c)
+	if !present && h.requireIfMatch {
+		RespondError(c, problem.CodePreconditionRequired, "If-Match header is required")
+		return nil, false
+	}
+	if versions != nil && len(versions) == 0 {
+		RespondError(c, problem.CodeVersionMismatch, "If-Match does not match the current version")
+		return nil, false
+	}
+	return versions, true
+}
+
+// noneMatch reports whether the If-None-Match header lists the entity tag (weak comparison)
+func noneMatch(c *gin.Context, etag string) bool {
+	header := strings.TrimSpace(c.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// newHeaderContext returns a gin context for a request with the given header.
+func newHeaderContext(t *testing.T, name, value string) (*gin.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/items/1", nil)
+	if value != "" {
+		c.Request.Header.Set(name, value)
+	}
+	return c, w
+}
+
+// =============================================================================
+// ifMatchVersions Tests
+// =============================================================================
+
+func TestIfMatchVersions_TableDriven(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantVersion []int64
+		wantPresent bool
+	}{
+		{
+			name:        "absent",
+			header:      "",
+			wantVersion: nil,
+			wantPresent: false,
+		},
+		{
+			name:        "any version",
+			header:      "*",
+			wantVersion: nil,
+			wantPresent: true,
+		},
+		{
+			name:        "single tag",
+			header:      `"3"`,
+			wantVersion: []int64{3},
+			wantPresent: true,
+		},
+		{
+			name:        "tag list",
+			header:      `"3", "4"`,
+			wantVersion: []int64{3, 4},
+			wantPresent: true,
+		},
+		{
+			name:        "weak tag never matches",
+			header:      `W/"3"`,
+			wantVersion: []int64{},
+			wantPresent: true,
+		},
+		{
+			name:        "foreign tags never match",
+			header:      `"abc", 3, "`,
+			wantVersion: []int64{},
+			wantPresent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newHeaderContext(t, "If-Match", tt.header)
+
+			versions, present := ifMatchVersions(c)
+
+			if present != tt.wantPresent {
+				t.Errorf("present = %v, want %v", present, tt.wantPresent)
+			}
+			if !reflect.DeepEqual(versions, tt.wantVersion) {
+				t.Errorf("versions = %#v, want %#v", versions, tt.wantVersion)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// writePreconditions Tests
+// =============================================================================
+
+func TestWritePreconditions_TableDriven(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		requireIfMatch bool
+		wantOK         bool
+		wantStatus     int
+	}{
+		{
+			name:   "optional and absent",
+			wantOK: true,
+		},
+		{
+			name:           "required and absent",
+			requireIfMatch: true,
+			wantStatus:     http.StatusPreconditionRequired,
+		},
+		{
+			name:           "required and present",
+			header:         `"1"`,
+			requireIfMatch: true,
+			wantOK:         true,
+		},
+		{
+			name:       "no matchable tag",
+			header:     `W/"1"`,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{requireIfMatch: tt.requireIfMatch}
+			c, w := newHeaderContext(t, "If-Match", tt.header)
+
+			_, ok := h.writePreconditions(c)
+
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// noneMatch Tests
+// =============================================================================
+
+func TestNoneMatch_TableDriven(t *testing.T) {
+	etag := versionETag(7)
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "absent", header: "", want: false},
+		{name: "same tag", header: `"7"`, want: true},
+		{name: "weak tag matches", header: `W/"7"`, want: true},
+		{name: "in list", header: `"6", "7"`, want: true},
+		{name: "any", header: "*", want: true},
+		{name: "other version", header: `"6"`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newHeaderContext(t, "If-None-Match", tt.header)
+
+			if got := noneMatch(c, etag); got != tt.want {
+				t.Errorf("noneMatch(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
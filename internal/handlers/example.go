@@ -1,28 +1,87 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
 	"github.com/gin-gonic/gin"
 )
 
 // GetItems godoc
-// @Summary Get all items
-// @Description Returns a list of all items
+// @Summary List items
+// @Description Returns a page of items. Pages are selected either by offset or by the opaque
+// @Description next_cursor of the previous page; cursors require sorting by created_at.
 // @Tags Items
 // @Produce json
-// @Success 200 {array} models.Item
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of items to skip"
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param sort query string false "Comma-separated fields (id, name, created_at, updated_at), prefix - for descending" default(-created_at)
+// @Param name_contains query string false "Case-insensitive substring of the name"
+// @Param created_after query string false "Only items created after this RFC 3339 time"
+// @Param created_before query string false "Only items created before this RFC 3339 time"
+// @Param include_total query bool false "Include the total number of matching items"
+// @Success 200 {object} models.ItemPage
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/items [get]
 func (h *Handler) GetItems(c *gin.Context) {
-	items, err := h.repo.GetAllItems(c.Request.Context())
+	var query models.ListItemsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	opts, msgErr := itemListOptions(query)
+	if msgErr != nil {
+		RespondError(c, problem.CodeInvalidRequest, msgErr.message, msgErr.params...)
+		return
+	}
+
+	page, err := h.repo.ListItems(c.Request.Context(), opts)
 	if err != nil {
-		LogAndRespondError(c, http.StatusInternalServerError, err, "Failed to retrieve items")
+		LogAndRespondError(c, err, "Failed to retrieve items")
 		return
 	}
-	c.JSON(http.StatusOK, items)
+	c.JSON(http.StatusOK, page)
+}
+
+// SearchItems godoc
+// @Summary Search items
+// @Description Full-text search over item names and descriptions, best match first.
+// @Description q supports web search syntax: words, "quoted phrases", or, and -excluded words.
+// @Description Highlights are HTML-escaped and wrap matches in <mark></mark>.
+// @Tags Items
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of results to skip"
+// @Success 200 {object} models.ItemSearchPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/items/search [get]
+func (h *Handler) SearchItems(c *gin.Context) {
+	var query models.SearchItemsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	page, err := h.repo.SearchItems(c.Request.Context(), repository.ItemSearchOptions{
+		Query:  query.Q,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to search items")
+		return
+	}
+	c.JSON(http.StatusOK, page)
 }
 
 // GetItem godoc
@@ -31,7 +90,10 @@ func (h *Handler) GetItems(c *gin.Context) {
 // @Tags Items
 // @Produce json
 // @Param id path int true "Item ID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} models.Item
+// @Header 200 {string} ETag "Item version"
+// @Success 304 "Not modified"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -39,7 +101,7 @@ func (h *Handler) GetItems(c *gin.Context) {
 func (h *Handler) GetItem(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		RespondError(c, http.StatusBadRequest, "Invalid ID format")
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
 		return
 	}
 
@@ -48,6 +110,12 @@ func (h *Handler) GetItem(c *gin.Context) {
 		HandleRepositoryError(c, err, "Item not found", "Failed to retrieve item")
 		return
 	}
+
+	setETag(c, item.Version)
+	if noneMatch(c, versionETag(item.Version)) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, item)
 }
 
@@ -59,14 +127,17 @@ func (h *Handler) GetItem(c *gin.Context) {
 // @Produce json
 // @Param item body models.CreateItemRequest true "Item data"
 // @Success 201 {object} models.Item
+// @Header 201 {string} ETag "Item version"
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/items [post]
 func (h *Handler) CreateItem(c *gin.Context) {
 	var req models.CreateItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondBindingError(c, err)
 		return
 	}
 
@@ -76,36 +147,49 @@ func (h *Handler) CreateItem(c *gin.Context) {
 	}
 
 	if err := h.repo.CreateItem(c.Request.Context(), item); err != nil {
-		LogAndRespondError(c, http.StatusInternalServerError, err, "Failed to create item")
+		LogAndRespondError(c, err, "Failed to create item")
 		return
 	}
+	setETag(c, item.Version)
 	c.JSON(http.StatusCreated, item)
 }
 
 // UpdateItem godoc
 // @Summary Update an item
-// @Description Updates an existing item
+// @Description Updates an existing item. Send the item's ETag in If-Match to avoid overwriting
+// @Description concurrent changes; a stale ETag is rejected with 412.
 // @Tags Items
 // @Accept json
 // @Produce json
 // @Param id path int true "Item ID"
+// @Param If-Match header string false "ETag of the version being replaced (required if REQUIRE_IF_MATCH is set)"
 // @Param item body models.UpdateItemRequest true "Item data"
 // @Success 200 {object} models.Item
+// @Header 200 {string} ETag "New item version"
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/items/{id} [put]
 func (h *Handler) UpdateItem(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		RespondError(c, http.StatusBadRequest, "Invalid ID format")
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
 		return
 	}
 
 	var req models.UpdateItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, err.Error())
+		RespondBindingError(c, err)
+		return
+	}
+
+	ifVersions, ok := h.writePreconditions(c)
+	if !ok {
 		return
 	}
 
@@ -115,34 +199,84 @@ func (h *Handler) UpdateItem(c *gin.Context) {
 		Description: req.Description,
 	}
 
-	if err := h.repo.UpdateItem(c.Request.Context(), item); err != nil {
+	if err := h.repo.UpdateItem(c.Request.Context(), item, ifVersions); err != nil {
 		HandleRepositoryError(c, err, "Item not found", "Failed to update item")
 		return
 	}
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
 // DeleteItem godoc
 // @Summary Delete an item
-// @Description Deletes an item by ID
+// @Description Moves an item to the trash, from where it can be restored until it is purged.
+// @Description Requires the "admin" role.
+// @Description With If-Match the item is only deleted if its ETag still matches.
 // @Tags Items
 // @Param id path int true "Item ID"
+// @Param If-Match header string false "ETag of the version being deleted (required if REQUIRE_IF_MATCH is set)"
 // @Success 204
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /api/v1/items/{id} [delete]
 func (h *Handler) DeleteItem(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		RespondError(c, http.StatusBadRequest, "Invalid ID format")
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
 		return
 	}
 
-	if err := h.repo.DeleteItem(c.Request.Context(), id); err != nil {
+	ifVersions, ok := h.writePreconditions(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteItem(c.Request.Context(), id, ifVersions); err != nil {
 		HandleRepositoryError(c, err, "Item not found", "Failed to delete item")
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// itemListOptions validates list query parameters and converts them to repository options
+func itemListOptions(query models.ListItemsQuery) (repository.ItemListOptions, *messageError) {
+	opts := repository.ItemListOptions{
+		Limit:         query.Limit,
+		Offset:        query.Offset,
+		NameContains:  query.NameContains,
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+		IncludeTotal:  query.IncludeTotal,
+	}
+
+	sort, err := repository.ParseSort(query.Sort, repository.ItemSortFields)
+	var sortErr *repository.SortError
+	if errors.As(err, &sortErr) {
+		if errors.Is(sortErr, repository.ErrDuplicateSortField) {
+			return opts, newMessageError(`duplicate sort field "{0}"`, sortErr.Field)
+		}
+		return opts, newMessageError(`cannot sort by "{0}" (allowed: {1})`, sortErr.Field, strings.Join(sortErr.Allowed, ", "))
+	}
+	opts.Sort = sort
+
+	if query.Cursor != "" {
+		if query.Offset > 0 {
+			return opts, newMessageError("cursor and offset cannot be combined")
+		}
+		if _, ok := repository.KeysetDirection(sort); len(sort) > 0 && !ok {
+			return opts, newMessageError("cursor requires sorting by created_at or -created_at")
+		}
+		opts.After, err = repository.DecodeCursor(query.Cursor)
+		if err != nil {
+			return opts, newMessageError("invalid cursor")
+		}
+	}
+	return opts, nil
+}
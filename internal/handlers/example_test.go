@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// =============================================================================
+// List Tests
+// =============================================================================
+
+func TestGetItems_LocalizesInvalidListOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(i18n.Middleware())
+	router.GET("/items", (&Handler{}).GetItems)
+
+	tests := []struct {
+		name     string
+		target   string
+		language string
+		want     string
+	}{
+		{
+			name:     "unknown sort field in English",
+			target:   "/items?sort=password_hash",
+			language: "en",
+			want:     `cannot sort by "password_hash" (allowed: id, name, created_at, updated_at)`,
+		},
+		{
+			name:     "unknown sort field in German",
+			target:   "/items?sort=password_hash",
+			language: "de",
+			want:     `Sortierung nach "password_hash" nicht möglich (erlaubt: id, name, created_at, updated_at)`,
+		},
+		{
+			name:     "duplicate sort field",
+			target:   "/items?sort=name,-name",
+			language: "de",
+			want:     `Sortierfeld "name" ist doppelt angegeben`,
+		},
+		{
+			name:     "invalid cursor",
+			target:   "/items?cursor=not-a-cursor",
+			language: "de",
+			want:     "Ungültiger cursor",
+		},
+		{
+			name:     "cursor with offset",
+			target:   "/items?cursor=abc&offset=5",
+			language: "de",
+			want:     "cursor und offset können nicht kombiniert werden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept-Language", tt.language)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+			}
+			if w.Code != http.StatusBadRequest || resp.Code != problem.CodeInvalidRequest || resp.Detail != tt.want {
+				t.Errorf("response = %d %q %q, want 400 invalid_request %q", w.Code, resp.Code, resp.Detail, tt.want)
+			}
+		})
+	}
+}
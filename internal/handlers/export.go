@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// Export and import formats
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// exportFlushRows is how many rows are buffered before being sent to the client
+const exportFlushRows = 500
+
+// exportWriteTimeout bounds each flush of an export. Large exports outlast the
+// server's write timeout, so the deadline is renewed before every flush instead.
+const exportWriteTimeout = time.Minute
+
+// exportColumns is the CSV header of an export; imports read name and description
+var exportColumns = []string{"id", "name", "description", "version", "created_at", "updated_at"}
+
+// ExportItems godoc
+// @Summary Export items
+// @Description Streams all items in ID order as CSV (with a header row) or NDJSON
+// @Description (one JSON item per line). Requires the "admin" role.
+// @Description Errors after streaming has started truncate the response.
+// @Tags Items
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "Export format" Enums(csv, ndjson) default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/items/export [get]
+func (h *Handler) ExportItems(c *gin.Context) {
+	var query models.ExportItemsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+	if query.Format == "" {
+		query.Format = formatCSV
+	}
+
+	// A client that stops reading fails the next flush within exportWriteTimeout
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {}
+	if err := rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to set write deadline for export", "error", err.Error())
+	} else {
+		extendDeadline = func() {
+			_ = rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+		}
+	}
+
+	// Rows are buffered, but the buffer also writes to the client whenever it
+	// fills up. Errors are answered with a JSON error only while c.Writer has
+	// not been written to; after that the response can only be truncated.
+	buf := bufio.NewWriter(c.Writer)
+	var write func(item *models.Item) error
+	var flush func() error
+	switch query.Format {
+	case formatCSV:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="items.csv"`)
+		w := csv.NewWriter(buf)
+		if err := w.Write(exportColumns); err != nil {
+			LogAndRespondError(c, err, "Failed to export items")
+			return
+		}
+		write = func(item *models.Item) error {
+			return w.Write([]string{
+				strconv.FormatInt(item.ID, 10),
+				item.Name,
+				item.Description,
+				strconv.FormatInt(item.Version, 10),
+				item.CreatedAt.UTC().Format(time.RFC3339Nano),
+				item.UpdatedAt.UTC().Format(time.RFC3339Nano),
+			})
+		}
+		flush = func() error {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+			return buf.Flush()
+		}
+	case formatNDJSON:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="items.ndjson"`)
+		encoder := json.NewEncoder(buf)
+		write = func(item *models.Item) error {
+			return encoder.Encode(item)
+		}
+		flush = buf.Flush
+	}
+
+	c.Status(http.StatusOK)
+	rows := 0
+	err := h.repo.StreamItems(c.Request.Context(), func(item *models.Item) error {
+		if err := write(item); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			extendDeadline()
+			if err := flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		extendDeadline()
+		err = flush()
+	}
+
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			LogAndRespondError(c, err, "Failed to export items")
+			return
+		}
+		// The status line is already sent; a truncated body is the only signal left
+		slog.ErrorContext(c.Request.Context(), "Export aborted", "format", query.Format, "rows", rows, "error", err.Error())
+		return
+	}
+	c.Writer.Flush()
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// exportRepo streams a fixed list of items, then returns err
+type exportRepo struct {
+	repository.Repository
+	items []models.Item
+	err   error
+}
+
+func (r *exportRepo) StreamItems(_ context.Context, fn func(item *models.Item) error) error {
+	for i := range r.items {
+		if err := fn(&r.items[i]); err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+// performExport requests an export in the given format
+func performExport(t *testing.T, repo *exportRepo, format string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/items/export", (&Handler{repo: repo}).ExportItems)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/export?format="+format, nil))
+	return w
+}
+
+var exportItems = []models.Item{
+	{ID: 1, Name: "Widget", Description: "Blue, small", Version: 2,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)},
+	{ID: 2, Name: "Gadget", Version: 1,
+		CreatedAt: time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC), UpdatedAt: time.Date(2024, 2, 2, 3, 4, 5, 0, time.UTC)},
+}
+
+// =============================================================================
+// ExportItems Tests
+// =============================================================================
+
+func TestExportItems_CSV(t *testing.T) {
+	w := performExport(t, &exportRepo{items: exportItems}, "csv")
+
+	want := "id,name,description,version,created_at,updated_at\n" +
+		"1,Widget,\"Blue, small\",2,2024-01-02T03:04:05Z,2024-01-03T03:04:05Z\n" +
+		"2,Gadget,,1,2024-02-02T03:04:05Z,2024-02-02T03:04:05Z\n"
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("ExportItems() = %d %q, want 200 %q", w.Code, w.Body.String(), want)
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Errorf("Content-Type = %q, want text/csv", got)
+	}
+}
+
+func TestExportItems_NDJSON(t *testing.T) {
+	w := performExport(t, &exportRepo{items: exportItems}, "ndjson")
+
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if w.Code != http.StatusOK || len(lines) != 2 {
+		t.Fatalf("ExportItems() = %d with %d lines, want 200 with 2", w.Code, len(lines))
+	}
+	if !strings.HasPrefix(lines[0], `{"id":1,"name":"Widget"`) {
+		t.Errorf("first line = %s", lines[0])
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", got)
+	}
+}
+
+func TestExportItems_ErrorBeforeFirstFlushRespondsWithError(t *testing.T) {
+	w := performExport(t, &exportRepo{items: exportItems, err: errors.New("connection lost")}, "csv")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != "" {
+		t.Errorf("Content-Disposition = %q, want none on errors", got)
+	}
+}
+
+func TestExportItems_RejectsUnknownFormat(t *testing.T) {
+	w := performExport(t, &exportRepo{}, "xml")
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -1,15 +1,41 @@
 package handlers
 
 import (
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/health"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
 	"github.com/GunarsK-templates/template-api/internal/repository"
+	"github.com/GunarsK-templates/template-api/internal/stream"
 )
 
 // Handler holds dependencies for HTTP handlers
 type Handler struct {
-	repo repository.Repository
+	repo   repository.Repository
+	tokens *auth.TokenIssuer // nil if tokens cannot be signed
+	jwks   auth.JWKS
+	broker *stream.Broker   // Item events for SSE clients
+	probes *health.Registry // Dependency checks of the health probes
+
+	requireIfMatch    bool
+	heartbeatInterval time.Duration // Of SSE streams
 }
 
 // New creates a new Handler instance
-func New(repo repository.Repository) *Handler {
-	return &Handler{repo: repo}
+func New(repo repository.Repository, cfg *config.Config, broker *stream.Broker, probes *health.Registry) *Handler {
+	h := &Handler{
+		repo:              repo,
+		broker:            broker,
+		probes:            probes,
+		requireIfMatch:    cfg.Service.RequireIfMatch,
+		heartbeatInterval: cfg.Stream.HeartbeatInterval,
+	}
+	if cfg.JWT.CanSign() {
+		h.tokens = auth.NewTokenIssuer(cfg.JWT)
+	}
+	if cfg.HasJWT() {
+		h.jwks = auth.NewJWKS(cfg.JWT)
+	}
+	return h
 }
@@ -4,17 +4,68 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/health"
 )
 
 // HealthCheck godoc
 // @Summary Health check
-// @Description Returns service health status
+// @Description Same as /health/ready, kept for existing monitors
 // @Tags Health
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
 // @Router /health [get]
 func (h *Handler) HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status": "healthy",
-	})
+	h.Readiness(c)
+}
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Description Reports whether the process works. Does not check external dependencies,
+// @Description so an outage of the database does not restart every instance.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/live [get]
+func (h *Handler) Liveness(c *gin.Context) {
+	respondProbe(c, h.probes.Run(c.Request.Context(), health.Live))
+}
+
+// Readiness godoc
+// @Summary Readiness probe
+// @Description Reports whether the instance can serve traffic: the database answers and all
+// @Description migrations are applied. Fails as soon as shutdown begins.
+// @Description Results are cached briefly; each check lists its status and latency.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/ready [get]
+func (h *Handler) Readiness(c *gin.Context) {
+	respondProbe(c, h.probes.Run(c.Request.Context(), health.Ready))
+}
+
+// Startup godoc
+// @Summary Startup probe
+// @Description Reports whether the instance has finished starting. Once it has passed it
+// @Description keeps passing without running the checks again.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /health/startup [get]
+func (h *Handler) Startup(c *gin.Context) {
+	respondProbe(c, h.probes.Run(c.Request.Context(), health.Startup))
+}
+
+// respondProbe answers a probe with its report, 503 if it failed
+func respondProbe(c *gin.Context, report health.Report) {
+	c.Header("Cache-Control", "no-store")
+	if !report.Healthy() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
 }
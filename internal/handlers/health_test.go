@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/health"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// performProbe requests a health probe and decodes its report
+func performProbe(t *testing.T, probes *health.Registry, path string) (int, health.Report) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{probes: probes}
+	router := gin.New()
+	router.GET("/health", h.HealthCheck)
+	router.GET("/health/live", h.Liveness)
+	router.GET("/health/ready", h.Readiness)
+	router.GET("/health/startup", h.Startup)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report health.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	return w.Code, report
+}
+
+// =============================================================================
+// Health Probe Tests
+// =============================================================================
+
+func TestHealthProbes_DatabaseDown(t *testing.T) {
+	probes := health.NewRegistry(time.Second, 0)
+	probes.Register("database", health.CheckerFunc(func(context.Context) error {
+		return errors.New("connection refused")
+	}), health.Ready|health.Startup)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{path: "/health/live", wantStatus: http.StatusOK},
+		{path: "/health/ready", wantStatus: http.StatusServiceUnavailable},
+		{path: "/health/startup", wantStatus: http.StatusServiceUnavailable},
+		{path: "/health", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			code, report := performProbe(t, probes, tt.path)
+
+			if code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", code, tt.wantStatus)
+			}
+			if code != http.StatusOK && report.Checks["database"].Error != "connection refused" {
+				t.Errorf("checks = %+v, want the database error", report.Checks)
+			}
+		})
+	}
+}
+
+func TestHealthProbes_ReadyUntilShutdown(t *testing.T) {
+	probes := health.NewRegistry(time.Second, 0)
+	probes.Register("database", health.CheckerFunc(func(context.Context) error { return nil }), health.Ready)
+
+	code, report := performProbe(t, probes, "/health/ready")
+	if code != http.StatusOK || report.Status != health.StatusHealthy || report.Checks["database"].Status != health.StatusHealthy {
+		t.Errorf("before shutdown: status = %d, report = %+v, want healthy", code, report)
+	}
+
+	probes.Shutdown()
+	code, report = performProbe(t, probes, "/health/ready")
+	if code != http.StatusServiceUnavailable || !report.ShuttingDown {
+		t.Errorf("after shutdown: status = %d, report = %+v, want 503 shutting down", code, report)
+	}
+}
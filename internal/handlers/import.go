@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// Import limits
+const (
+	maxImportBytes     = 64 << 20 // Request body
+	maxImportLineBytes = 1 << 20  // Single NDJSON line
+	maxImportErrors    = 100      // Line errors listed in the response
+	importBatchSize    = 100      // Rows inserted per CreateItems call
+)
+
+// importReadTimeout bounds reading each batch of rows. Large imports outlast the
+// server's read timeout, so the deadline is renewed per batch instead; a stalled
+// client cannot hold the import transaction open.
+const importReadTimeout = time.Minute
+
+// errImportInvalid rolls back an import that contains invalid rows
+var errImportInvalid = errors.New("import contains invalid rows")
+
+// importFormatError is a problem with the import document as a whole, answered with 400
+type importFormatError struct {
+	messageError
+}
+
+func newImportFormatError(message string, params ...string) *importFormatError {
+	return &importFormatError{messageError{message: message, params: params}}
+}
+
+// ImportItems godoc
+// @Summary Import items
+// @Description Creates items from CSV (header row with a "name" and optionally a "description"
+// @Description column; other columns such as those of an export are ignored) or NDJSON
+// @Description (one object per line). Every row is validated like a create request.
+// @Description The import is all or nothing: if any row is invalid nothing is stored and the
+// @Description response lists the first line errors. dry_run=true only validates.
+// @Description Requires the "admin" role.
+// @Tags Items
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "Import format" Enums(csv, ndjson) default(csv)
+// @Param dry_run query bool false "Only validate, do not store anything"
+// @Param file body string true "CSV or NDJSON document"
+// @Success 200 {object} models.ImportItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 422 {object} models.ImportItemsResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/items/import [post]
+func (h *Handler) ImportItems(c *gin.Context) {
+	var query models.ImportItemsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+	if query.Format == "" {
+		query.Format = formatCSV
+	}
+
+	// A client that stops sending fails the next read within importReadTimeout
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {}
+	if err := rc.SetReadDeadline(time.Now().Add(importReadTimeout)); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to set read deadline for import", "error", err.Error())
+	} else {
+		extendDeadline = func() {
+			_ = rc.SetReadDeadline(time.Now().Add(importReadTimeout))
+		}
+	}
+
+	ctx := c.Request.Context()
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	resp := models.ImportItemsResponse{DryRun: query.DryRun}
+
+	var batch []*models.Item
+	importRows := func(repo repository.Repository) error {
+		insert := func() error {
+			if query.DryRun || resp.Invalid > 0 || len(batch) == 0 {
+				return nil
+			}
+			if err := repo.CreateItems(ctx, batch); err != nil {
+				return err
+			}
+			resp.Imported += len(batch)
+			batch = batch[:0]
+			return nil
+		}
+
+		rows := 0
+		err := readImportRows(query.Format, body, func(line int, req *models.CreateItemRequest, rowErr error) error {
+			if rows++; rows%importBatchSize == 0 {
+				extendDeadline()
+			}
+			if rowErr == nil {
+				rowErr = binding.Validator.ValidateStruct(req)
+			}
+			if rowErr != nil {
+				resp.Invalid++
+				if len(resp.Errors) < maxImportErrors {
+					resp.Errors = append(resp.Errors, models.ImportLineError{Line: line, Error: validationMessage(ctx, rowErr)})
+				}
+				return nil
+			}
+
+			resp.Valid++
+			batch = append(batch, &models.Item{Name: req.Name, Description: req.Description})
+			if len(batch) < importBatchSize {
+				return nil
+			}
+			// Once a row is invalid nothing will be stored, keep validating only
+			if query.DryRun || resp.Invalid > 0 {
+				batch = batch[:0]
+				return nil
+			}
+			return insert()
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Invalid > 0 {
+			return errImportInvalid
+		}
+		return insert()
+	}
+
+	var err error
+	if query.DryRun {
+		err = importRows(nil)
+	} else {
+		err = h.repo.Transaction(ctx, importRows)
+	}
+
+	var formatErr *importFormatError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, errImportInvalid):
+		resp.Imported = 0
+		c.JSON(http.StatusUnprocessableEntity, resp)
+	case errors.As(err, &formatErr):
+		RespondError(c, problem.CodeInvalidRequest, formatErr.message, formatErr.params...)
+	case errors.As(err, &tooLarge):
+		RespondError(c, problem.CodePayloadTooLarge, "Import is larger than {0} bytes", strconv.Itoa(maxImportBytes))
+	case err != nil:
+		LogAndRespondError(c, err, "Failed to import items")
+	default:
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// readImportRows parses an import document and calls fn for each row with its line number.
+// Rows that cannot be parsed are passed with rowErr set. An error from fn, from reading the
+// body, or an *importFormatError for an unusable document stops reading and is returned.
+func readImportRows(format string, body io.Reader, fn func(line int, req *models.CreateItemRequest, rowErr error) error) error {
+	if format == formatNDJSON {
+		return readNDJSONRows(body, fn)
+	}
+	return readCSVRows(body, fn)
+}
+
+// readCSVRows reads CSV rows, mapping columns by the header row
+func readCSVRows(body io.Reader, fn func(line int, req *models.CreateItemRequest, rowErr error) error) error {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return newImportFormatError("CSV header row is missing")
+	}
+	if err != nil {
+		return csvReadError(err)
+	}
+
+	nameColumn, descriptionColumn := -1, -1
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff") // Byte order mark from spreadsheet exports
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		switch column {
+		case "name":
+			nameColumn = i
+		case "description":
+			descriptionColumn = i
+		}
+	}
+	if nameColumn < 0 {
+		return newImportFormatError(`CSV header must contain a "name" column`)
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := fn(parseErr.StartLine, nil, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+		req := &models.CreateItemRequest{Name: record[nameColumn]}
+		if descriptionColumn >= 0 {
+			req.Description = record[descriptionColumn]
+		}
+		if err := fn(line, req, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// csvReadError converts a CSV header error into an *importFormatError
+func csvReadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return newImportFormatError("Invalid CSV header: {0}", parseErr.Error())
+	}
+	return err
+}
+
+// readNDJSONRows reads one JSON object per line, skipping blank lines
+func readNDJSONRows(body io.Reader, fn func(line int, req *models.CreateItemRequest, rowErr error) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxImportLineBytes)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := scanner.Bytes()
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		// Unknown fields are allowed so exports can be imported again
+		var req models.CreateItemRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			if err := fn(line, nil, newMessageError("Invalid JSON: {0}", err.Error())); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(line, &req, nil); err != nil {
+			return err
+		}
+	}
+
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return newImportFormatError("Line {0} is longer than {1} bytes", strconv.Itoa(line+1), strconv.Itoa(maxImportLineBytes))
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// importRepo records items created in committed transactions
+type importRepo struct {
+	repository.Repository
+	pending   []*models.Item
+	committed []*models.Item
+}
+
+func (r *importRepo) Transaction(_ context.Context, fn func(tx repository.Repository) error) error {
+	r.pending = nil
+	if err := fn(r); err != nil {
+		return err
+	}
+	r.committed = append(r.committed, r.pending...)
+	return nil
+}
+
+func (r *importRepo) CreateItems(_ context.Context, items []*models.Item) error {
+	for _, item := range items {
+		copied := *item
+		r.pending = append(r.pending, &copied)
+	}
+	return nil
+}
+
+// performImport posts an import document and decodes the summary
+func performImport(t *testing.T, repo *importRepo, query, body string) (int, models.ImportItemsResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/items/import", (&Handler{repo: repo}).ImportItems)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items/import"+query, strings.NewReader(body)))
+
+	var resp models.ImportItemsResponse
+	if w.Code == http.StatusOK || w.Code == http.StatusUnprocessableEntity {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+		}
+	}
+	return w.Code, resp
+}
+
+// =============================================================================
+// ImportItems Tests
+// =============================================================================
+
+func TestImportItems_CSVWithExportColumns(t *testing.T) {
+	repo := &importRepo{}
+	body := "\ufeffid,Name,description,version\n" +
+		"1,Widget,\"Blue, small\",2\n" +
+		"\n" +
+		"2,Gadget,,1\n"
+
+	code, resp := performImport(t, repo, "", body)
+
+	if code != http.StatusOK || resp.Imported != 2 || len(repo.committed) != 2 {
+		t.Fatalf("import = %d %+v, committed %d, want 200 with 2 imported", code, resp, len(repo.committed))
+	}
+	if got := repo.committed[0]; got.Name != "Widget" || got.Description != "Blue, small" || got.ID != 0 {
+		t.Errorf("first item = %+v", got)
+	}
+}
+
+func TestImportItems_NDJSON(t *testing.T) {
+	repo := &importRepo{}
+	body := `{"id": 9, "name": "Widget", "description": "Blue"}` + "\n\n" + `{"name": "Gadget"}`
+
+	code, resp := performImport(t, repo, "?format=ndjson", body)
+
+	if code != http.StatusOK || resp.Valid != 2 || len(repo.committed) != 2 {
+		t.Errorf("import = %d %+v, committed %d, want 200 with 2 imported", code, resp, len(repo.committed))
+	}
+}
+
+func TestImportItems_InvalidRowsRollBack(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		body      string
+		wantLines []int
+	}{
+		{
+			name:      "csv missing name and wrong field count",
+			body:      "name,description\nWidget,ok\n,empty name\nA,B,C\n",
+			wantLines: []int{3, 4},
+		},
+		{
+			name:      "csv name too long",
+			body:      "name\n" + strings.Repeat("x", 201) + "\n",
+			wantLines: []int{2},
+		},
+		{
+			name:      "ndjson bad json and wrong type",
+			query:     "?format=ndjson",
+			body:      `{"name": "Widget"}` + "\n" + `{"name": ` + "\n\n" + `{"name": 5}` + "\n",
+			wantLines: []int{2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &importRepo{}
+
+			code, resp := performImport(t, repo, tt.query, tt.body)
+
+			if code != http.StatusUnprocessableEntity || resp.Imported != 0 || len(repo.committed) != 0 {
+				t.Fatalf("import = %d %+v, committed %d, want 422 with nothing imported", code, resp, len(repo.committed))
+			}
+			if len(resp.Errors) != len(tt.wantLines) {
+				t.Fatalf("errors = %+v, want lines %v", resp.Errors, tt.wantLines)
+			}
+			for i, line := range tt.wantLines {
+				if resp.Errors[i].Line != line {
+					t.Errorf("errors[%d].Line = %d, want %d", i, resp.Errors[i].Line, line)
+				}
+			}
+		})
+	}
+}
+
+func TestImportItems_DryRunStoresNothing(t *testing.T) {
+	repo := &importRepo{}
+
+	code, resp := performImport(t, repo, "?dry_run=true", "name\nWidget\nGadget\n")
+
+	if code != http.StatusOK || resp.Valid != 2 || resp.Imported != 0 || !resp.DryRun || len(repo.committed) != 0 {
+		t.Errorf("import = %d %+v, committed %d, want 200 with 2 valid and nothing stored", code, resp, len(repo.committed))
+	}
+}
+
+func TestImportItems_InsertsInBatches(t *testing.T) {
+	repo := &importRepo{}
+	body := "name\n" + strings.Repeat("Widget\n", importBatchSize*2+1)
+
+	code, resp := performImport(t, repo, "", body)
+
+	if code != http.StatusOK || resp.Imported != importBatchSize*2+1 || len(repo.committed) != importBatchSize*2+1 {
+		t.Errorf("import = %d %+v, committed %d, want all %d rows", code, resp, len(repo.committed), importBatchSize*2+1)
+	}
+}
+
+func TestImportItems_RejectsUnusableDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{name: "empty csv", body: ""},
+		{name: "csv without name column", body: "title\nWidget\n"},
+		{name: "ndjson line too long", query: "?format=ndjson", body: strings.Repeat("x", maxImportLineBytes+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := performImport(t, &importRepo{}, tt.query, tt.body)
+			if code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", code, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// Patch document media types
+const (
+	mediaTypeMergePatch = "application/merge-patch+json" // RFC 7396
+	mediaTypeJSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+// maxPatchBytes limits the size of PATCH request bodies
+const maxPatchBytes = 1 << 20
+
+// patchAttempts bounds how often a PATCH without If-Match is re-applied
+// when the item changes between reading and writing it
+const patchAttempts = 3
+
+// PatchItem godoc
+// @Summary Patch an item
+// @Description Applies a JSON Merge Patch (application/merge-patch+json) or a JSON Patch
+// @Description (application/json-patch+json) to the editable fields {"name", "description"}.
+// @Description The result is validated like a PUT body; only changed fields are written.
+// @Tags Items
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param If-Match header string false "ETag of the version being patched (required if REQUIRE_IF_MATCH is set)"
+// @Param patch body object true "Merge patch object or JSON Patch operation array"
+// @Success 200 {object} models.Item
+// @Header 200 {string} ETag "Item version"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/items/{id} [patch]
+func (h *Handler) PatchItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != mediaTypeMergePatch && contentType != mediaTypeJSONPatch {
+		c.Header("Accept-Patch", mediaTypeMergePatch+", "+mediaTypeJSONPatch)
+		RespondError(c, problem.CodeUnsupportedMediaType, "Content-Type must be {0} or {1}", mediaTypeMergePatch, mediaTypeJSONPatch)
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			RespondError(c, problem.CodePayloadTooLarge, "Patch document is too large")
+			return
+		}
+		RespondError(c, problem.CodeInvalidRequest, "Failed to read request body")
+		return
+	}
+
+	ifVersions, ok := h.writePreconditions(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	for attempt := 1; ; attempt++ {
+		item, err := h.repo.GetItemByID(ctx, id)
+		if err != nil {
+			HandleRepositoryError(c, err, "Item not found", "Failed to patch item")
+			return
+		}
+		if ifVersions != nil && !slices.Contains(ifVersions, item.Version) {
+			RespondError(c, problem.CodeVersionMismatch, "If-Match does not match the current version")
+			return
+		}
+
+		patched, perr := applyItemPatch(ctx, contentType, patch, item)
+		if perr != nil {
+			problem.Respond(c, perr)
+			return
+		}
+
+		columns := changedItemColumns(item, patched)
+		if len(columns) == 0 {
+			setETag(c, item.Version)
+			c.JSON(http.StatusOK, item)
+			return
+		}
+
+		// Write against the version the patch was applied to
+		item.Name, item.Description = patched.Name, patched.Description
+		err = h.repo.PatchItem(ctx, item, columns, []int64{item.Version})
+		if errors.Is(err, repository.ErrVersionMismatch) && ifVersions == nil && attempt < patchAttempts {
+			continue
+		}
+		if err != nil {
+			HandleRepositoryError(c, err, "Item not found", "Failed to patch item")
+			return
+		}
+
+		setETag(c, item.Version)
+		c.JSON(http.StatusOK, item)
+		return
+	}
+}
+
+// applyItemPatch applies a patch document to the editable fields of item
+// and validates the result with the UpdateItemRequest binding rules.
+// A failure is returned as the problem to respond with.
+func applyItemPatch(ctx context.Context, contentType string, patch []byte, item *models.Item) (models.UpdateItemRequest, *problem.Details) {
+	var req models.UpdateItemRequest
+
+	// Both fields are always present so JSON Patch can replace or remove either
+	doc, err := json.Marshal(map[string]string{
+		"name":        item.Name,
+		"description": item.Description,
+	})
+	if err != nil {
+		return req, problem.New(problem.CodeInternal, i18n.T(ctx, "Failed to patch item"))
+	}
+
+	var patched []byte
+	switch contentType {
+	case mediaTypeMergePatch:
+		patched, err = jsonpatch.MergePatch(doc, patch)
+		if err != nil {
+			return req, problem.New(problem.CodeInvalidRequest, i18n.T(ctx, "Invalid merge patch: {0}", err.Error()))
+		}
+	case mediaTypeJSONPatch:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return req, problem.New(problem.CodeInvalidRequest, i18n.T(ctx, "Invalid JSON Patch: {0}", err.Error()))
+		}
+		patched, err = operations.Apply(doc)
+		if err != nil {
+			return req, problem.New(problem.CodePatchConflict, i18n.T(ctx, "Patch cannot be applied: {0}", err.Error()))
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		if fields := problem.FieldErrors(ctx, err); len(fields) > 0 {
+			return req, problem.Invalid(problem.CodePatchResultInvalid, fields)
+		}
+		return req, problem.New(problem.CodePatchResultInvalid, i18n.T(ctx, "Patched item is invalid: {0}", err.Error()))
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return req, problem.Invalid(problem.CodePatchResultInvalid, problem.FieldErrors(ctx, err))
+	}
+	return req, nil
+}
+
+// changedItemColumns lists the columns whose values differ between item and patched
+func changedItemColumns(item *models.Item, patched models.UpdateItemRequest) []string {
+	var columns []string
+	if patched.Name != item.Name {
+		columns = append(columns, "name")
+	}
+	if patched.Description != item.Description {
+		columns = append(columns, "description")
+	}
+	return columns
+}
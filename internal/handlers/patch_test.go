@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// patchRepo stubs the item reads and patches used by PatchItem.
+// Unused Repository methods panic via the nil embedded interface.
+type patchRepo struct {
+	repository.Repository
+	item      models.Item
+	conflicts int // PatchItem calls to fail with ErrVersionMismatch
+	columns   []string
+	calls     int
+}
+
+func (r *patchRepo) GetItemByID(_ context.Context, _ int64) (*models.Item, error) {
+	item := r.item
+	return &item, nil
+}
+
+func (r *patchRepo) PatchItem(_ context.Context, item *models.Item, columns []string, _ []int64) error {
+	r.calls++
+	if r.calls <= r.conflicts {
+		r.item.Version++ // someone else updated the item
+		return repository.ErrVersionMismatch
+	}
+	r.columns = columns
+	item.Version++
+	r.item = *item
+	return nil
+}
+
+// performPatch sends a PATCH for item 1 through PatchItem.
+func performPatch(t *testing.T, repo *patchRepo, contentType, body, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.PATCH("/items/:id", (&Handler{repo: repo}).PatchItem)
+
+	req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// =============================================================================
+// applyItemPatch Tests
+// =============================================================================
+
+func TestApplyItemPatch_TableDriven(t *testing.T) {
+	item := &models.Item{ID: 1, Name: "Widget", Description: "Blue", Version: 2}
+
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		want        models.UpdateItemRequest
+		wantStatus  int
+	}{
+		{
+			name:        "merge patch changes name",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"name": "Gadget"}`,
+			want:        models.UpdateItemRequest{Name: "Gadget", Description: "Blue"},
+		},
+		{
+			name:        "merge patch null removes description",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"description": null}`,
+			want:        models.UpdateItemRequest{Name: "Widget"},
+		},
+		{
+			name:        "json patch replace and test",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "test", "path": "/name", "value": "Widget"}, {"op": "replace", "path": "/description", "value": "Red"}]`,
+			want:        models.UpdateItemRequest{Name: "Widget", Description: "Red"},
+		},
+		{
+			name:        "json patch copy",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "copy", "from": "/name", "path": "/description"}]`,
+			want:        models.UpdateItemRequest{Name: "Widget", Description: "Widget"},
+		},
+		{
+			name:        "malformed merge patch",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"name":`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "malformed json patch",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "explode", "path": "/name"}]`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "failed test operation",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "test", "path": "/name", "value": "Other"}]`,
+			wantStatus:  http.StatusConflict,
+		},
+		{
+			name:        "missing path",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "replace", "path": "/missing/deep", "value": 1}]`,
+			wantStatus:  http.StatusConflict,
+		},
+		{
+			name:        "removing required name",
+			contentType: mediaTypeJSONPatch,
+			patch:       `[{"op": "remove", "path": "/name"}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "name too long",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"name": "` + strings.Repeat("x", 201) + `"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "read-only field",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"version": 99}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "wrong type",
+			contentType: mediaTypeMergePatch,
+			patch:       `{"name": 5}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, perr := applyItemPatch(context.Background(), tt.contentType, []byte(tt.patch), item)
+
+			if tt.wantStatus != 0 {
+				if perr == nil || perr.Status != tt.wantStatus {
+					t.Errorf("applyItemPatch() error = %+v, want status %d", perr, tt.wantStatus)
+				}
+				return
+			}
+			if perr != nil {
+				t.Fatalf("applyItemPatch() error = %+v", perr)
+			}
+			if got != tt.want {
+				t.Errorf("applyItemPatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// PatchItem Tests
+// =============================================================================
+
+func TestPatchItem_WritesOnlyChangedColumns(t *testing.T) {
+	repo := &patchRepo{item: models.Item{ID: 1, Name: "Widget", Description: "Blue", Version: 2}}
+
+	w := performPatch(t, repo, mediaTypeMergePatch, `{"name": "Gadget", "description": "Blue"}`, `"2"`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if !reflect.DeepEqual(repo.columns, []string{"name"}) {
+		t.Errorf("columns = %v, want [name]", repo.columns)
+	}
+	if got := w.Header().Get("ETag"); got != `"3"` {
+		t.Errorf("ETag = %q, want %q", got, `"3"`)
+	}
+}
+
+func TestPatchItem_NoChangesSkipsWrite(t *testing.T) {
+	repo := &patchRepo{item: models.Item{ID: 1, Name: "Widget", Version: 2}}
+
+	w := performPatch(t, repo, mediaTypeMergePatch, `{"name": "Widget"}`, "")
+
+	if w.Code != http.StatusOK || repo.calls != 0 {
+		t.Errorf("status = %d, PatchItem calls = %d, want 200 without write", w.Code, repo.calls)
+	}
+}
+
+func TestPatchItem_RetriesConcurrentUpdateWithoutIfMatch(t *testing.T) {
+	repo := &patchRepo{item: models.Item{ID: 1, Name: "Widget", Version: 2}, conflicts: 1}
+
+	w := performPatch(t, repo, mediaTypeJSONPatch, `[{"op": "replace", "path": "/name", "value": "Gadget"}]`, "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if repo.calls != 2 {
+		t.Errorf("PatchItem calls = %d, want 2", repo.calls)
+	}
+}
+
+func TestPatchItem_RejectsStaleIfMatch(t *testing.T) {
+	repo := &patchRepo{item: models.Item{ID: 1, Name: "Widget", Version: 5}}
+
+	w := performPatch(t, repo, mediaTypeMergePatch, `{"name": "Gadget"}`, `"4"`)
+
+	if w.Code != http.StatusPreconditionFailed || repo.calls != 0 {
+		t.Errorf("status = %d, PatchItem calls = %d, want 412 without write", w.Code, repo.calls)
+	}
+}
+
+func TestPatchItem_RejectsUnsupportedMediaType(t *testing.T) {
+	repo := &patchRepo{item: models.Item{ID: 1, Name: "Widget", Version: 1}}
+
+	w := performPatch(t, repo, "application/json", `{"name": "Gadget"}`, "")
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+	if w.Header().Get("Accept-Patch") == "" {
+		t.Error("Accept-Patch header should list supported patch formats")
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// searchRepo returns page from SearchItems and records its options
+type searchRepo struct {
+	repository.Repository
+	page  *models.ItemSearchPage
+	opts  repository.ItemSearchOptions
+	calls int
+}
+
+func (r *searchRepo) SearchItems(_ context.Context, opts repository.ItemSearchOptions) (*models.ItemSearchPage, error) {
+	r.opts = opts
+	r.calls++
+	return r.page, nil
+}
+
+// performSearch sends a GET request to the search route
+func performSearch(repo *searchRepo, target string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{repo: repo}
+	router := gin.New()
+	router.GET("/items/search", h.SearchItems)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+// =============================================================================
+// Search Tests
+// =============================================================================
+
+func TestSearchItems_ReturnsRankedResultsWithHighlights(t *testing.T) {
+	repo := &searchRepo{page: &models.ItemSearchPage{Items: []models.ItemSearchResult{{
+		Item:                 models.Item{ID: 3, Name: "Blue <b>widget</b>", Version: 1},
+		Rank:                 0.61,
+		NameHighlight:        "Blue &lt;b&gt;<mark>widget</mark>&lt;/b&gt;",
+		DescriptionHighlight: "a <mark>widget</mark> for",
+	}}}}
+
+	w := performSearch(repo, `/items/search?q=%22blue+widget%22+-red&limit=5&offset=10`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", w.Code, w.Body.String())
+	}
+	want := repository.ItemSearchOptions{Query: `"blue widget" -red`, Limit: 5, Offset: 10}
+	if repo.opts != want {
+		t.Errorf("opts = %+v, want %+v", repo.opts, want)
+	}
+
+	var page models.ItemSearchPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("items = %+v, want 1 result", page.Items)
+	}
+	got := page.Items[0]
+	if got.ID != 3 || got.Name != "Blue <b>widget</b>" || got.Rank != 0.61 ||
+		got.NameHighlight != "Blue &lt;b&gt;<mark>widget</mark>&lt;/b&gt;" ||
+		got.DescriptionHighlight != "a <mark>widget</mark> for" {
+		t.Errorf("result = %+v, want the repository result unchanged", got)
+	}
+}
+
+func TestSearchItems_RejectsInvalidQueries(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{name: "missing q", target: "/items/search"},
+		{name: "empty q", target: "/items/search?q="},
+		{name: "q too long", target: "/items/search?q=" + strings.Repeat("a", 201)},
+		{name: "limit below 1", target: "/items/search?q=widget&limit=-1"},
+		{name: "limit above 100", target: "/items/search?q=widget&limit=101"},
+		{name: "negative offset", target: "/items/search?q=widget&offset=-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &searchRepo{}
+
+			w := performSearch(repo, tt.target)
+
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+			}
+			if w.Code != http.StatusBadRequest || resp.Code != problem.CodeValidationFailed {
+				t.Errorf("status = %d, code = %q, want 400 validation_failed", w.Code, resp.Code)
+			}
+			if repo.calls != 0 {
+				t.Error("invalid queries should not reach the repository")
+			}
+		})
+	}
+}
+
+func TestSearchItems_AcceptsLimitBounds(t *testing.T) {
+	for _, limit := range []string{"1", "100"} {
+		t.Run(limit, func(t *testing.T) {
+			repo := &searchRepo{page: &models.ItemSearchPage{Items: []models.ItemSearchResult{}}}
+
+			w := performSearch(repo, "/items/search?q=widget&limit="+limit)
+
+			if w.Code != http.StatusOK || repo.calls != 1 {
+				t.Errorf("status = %d, calls = %d, want 200 and one search", w.Code, repo.calls)
+			}
+		})
+	}
+}
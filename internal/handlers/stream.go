@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/stream"
+)
+
+// streamWriteTimeout bounds each write to an event stream. Streams outlast the
+// server's write timeout, so the deadline is set before every write instead.
+const streamWriteTimeout = 30 * time.Second
+
+// StreamItemEvents godoc
+// @Summary Stream item changes
+// @Description Server-Sent Events stream of item changes from all replicas. Each event has the
+// @Description event ID as "id", ItemCreated, ItemUpdated or ItemDeleted as "event" and the item as
+// @Description "data" (only id and version for very large items). Reconnecting with Last-Event-ID
+// @Description replays the buffered events after it; if it is no longer buffered a "reset" event
+// @Description is sent first and the client should reload items. Idle streams get heartbeat comments.
+// @Tags Items
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/items/events [get]
+func (h *Handler) StreamItemEvents(c *gin.Context) {
+	var lastEventID int64
+	resume := false
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			RespondError(c, problem.CodeInvalidRequest, "Invalid Last-Event-ID")
+			return
+		}
+		lastEventID, resume = id, true
+	}
+
+	// A client that stops reading fails the next write within streamWriteTimeout
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() {}
+	if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		slog.WarnContext(c.Request.Context(), "Failed to set write deadline for event stream", "error", err.Error())
+	} else {
+		extendDeadline = func() {
+			_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		}
+	}
+
+	sub, replay, ok := h.broker.Subscribe(lastEventID, resume)
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	if !ok {
+		if _, err := io.WriteString(w, "event: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+	for _, event := range replay {
+		if err := writeStreamEvent(w, event); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				return // Dropped for falling behind or by a reset; the client reconnects
+			}
+			extendDeadline()
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			extendDeadline()
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		w.Flush()
+	}
+}
+
+// writeStreamEvent writes one event in SSE format; its data is single-line JSON
+func writeStreamEvent(w io.Writer, event stream.Event) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/stream"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// openItemStream starts an SSE request and returns its response, reading lines in the background
+func openItemStream(t *testing.T, broker *stream.Broker, lastEventID string) (*http.Response, <-chan string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{broker: broker, heartbeatInterval: 20 * time.Millisecond}
+	router := gin.New()
+	router.GET("/items/events", h.StreamItemEvents)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() }) //nolint:errcheck // test cleanup
+
+	lines := make(chan string, 100)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	return resp, lines
+}
+
+// readUntil collects stream lines until one equals want
+func readUntil(t *testing.T, lines <-chan string, want string) []string {
+	t.Helper()
+	var got []string
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("stream ended before %q, got %q", want, got)
+			}
+			got = append(got, line)
+			if line == want {
+				return got
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q, got %q", want, got)
+		}
+	}
+}
+
+func streamEvent(id int64, eventType string) stream.Event {
+	return stream.Event{ID: id, Type: eventType, Data: json.RawMessage(`{"id":7,"version":1}`)}
+}
+
+// =============================================================================
+// StreamItemEvents Tests
+// =============================================================================
+
+func TestStreamItemEvents_ReplaysThenStreamsLive(t *testing.T) {
+	broker := stream.NewBroker(10)
+	broker.Publish(streamEvent(1, "ItemCreated"))
+	broker.Publish(streamEvent(2, "ItemUpdated"))
+
+	resp, lines := openItemStream(t, broker, "1")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status = %d, Content-Type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	got := readUntil(t, lines, "id: 2")
+	if len(got) != 1 {
+		t.Errorf("replay started with %q, want event 2 only", got)
+	}
+	readUntil(t, lines, `data: {"id":7,"version":1}`)
+
+	readUntil(t, lines, ": heartbeat")
+	broker.Publish(streamEvent(3, "ItemDeleted"))
+	got = readUntil(t, lines, "event: ItemDeleted")
+	if got[len(got)-2] != "id: 3" {
+		t.Errorf("live event lines = %q, want id 3", got)
+	}
+}
+
+func TestStreamItemEvents_SendsResetWhenResumeIsImpossible(t *testing.T) {
+	broker := stream.NewBroker(10)
+	broker.Publish(streamEvent(5, "ItemCreated"))
+
+	_, lines := openItemStream(t, broker, "4")
+
+	got := readUntil(t, lines, "event: reset")
+	if len(got) != 1 {
+		t.Errorf("stream started with %q, want the reset event", got)
+	}
+
+	broker.Reset() // As on shutdown
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-lines:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("stream should end when the broker is reset")
+		}
+	}
+}
+
+func TestStreamItemEvents_RejectsInvalidLastEventID(t *testing.T) {
+	resp, _ := openItemStream(t, stream.NewBroker(10), "abc")
+
+	if resp.StatusCode != http.StatusBadRequest || resp.Header.Get("Content-Type") != problem.ContentType {
+		t.Errorf("status = %d, want 400 problem", resp.StatusCode)
+	}
+}
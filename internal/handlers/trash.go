@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// ListTrash godoc
+// @Summary List deleted items
+// @Description Returns a page of items in the trash, most recently deleted first.
+// @Description Requires the "admin" role.
+// @Tags Trash
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} models.ItemPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/items/trash [get]
+func (h *Handler) ListTrash(c *gin.Context) {
+	var query models.ListTrashQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	page, err := h.repo.ListDeletedItems(c.Request.Context(), query.Limit, query.Offset)
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve deleted items")
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// RestoreItem godoc
+// @Summary Restore a deleted item
+// @Description Moves an item out of the trash. Its version is incremented.
+// @Description Requires the "admin" role.
+// @Tags Trash
+// @Produce json
+// @Param id path int true "Item ID"
+// @Success 200 {object} models.Item
+// @Header 200 {string} ETag "Item version"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/items/{id}/restore [post]
+func (h *Handler) RestoreItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	item, err := h.repo.RestoreItem(c.Request.Context(), id)
+	if err != nil {
+		HandleRepositoryError(c, err, "Item not found in trash", "Failed to restore item")
+		return
+	}
+	setETag(c, item.Version)
+	c.JSON(http.StatusOK, item)
+}
+
+// PurgeItem godoc
+// @Summary Permanently delete an item
+// @Description Permanently deletes an item that is in the trash. This cannot be undone.
+// @Description Requires the "admin" role.
+// @Tags Trash
+// @Param id path int true "Item ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/items/trash/{id} [delete]
+func (h *Handler) PurgeItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	if err := h.repo.PurgeItem(c.Request.Context(), id); err != nil {
+		HandleRepositoryError(c, err, "Item not found in trash", "Failed to purge item")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
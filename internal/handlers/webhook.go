@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+	"github.com/GunarsK-templates/template-api/internal/webhooks"
+)
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribes a URL to item events. Every delivery is a POST of the event JSON signed
+// @Description with the subscription secret in X-Signature ("t=<unix seconds>,v1=<hex HMAC-SHA256
+// @Description of "<t>.<body>">"). The secret is generated unless given and is returned only in
+// @Description this response. Requires the "admin" role.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.CreateWebhookRequest true "Subscription data"
+// @Success 201 {object} models.CreateWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = webhooks.NewSecret(); err != nil {
+			LogAndRespondError(c, err, "Failed to create webhook")
+			return
+		}
+	}
+
+	sub := models.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+		Active:     req.Active == nil || *req.Active,
+	}
+	if err := h.repo.CreateWebhook(c.Request.Context(), &sub); err != nil {
+		LogAndRespondError(c, err, "Failed to create webhook")
+		return
+	}
+	c.JSON(http.StatusCreated, models.CreateWebhookResponse{WebhookSubscription: sub, Secret: secret})
+}
+
+// ListWebhooks godoc
+// @Summary List webhook subscriptions
+// @Description Returns all webhook subscriptions without their secrets. Requires the "admin" role.
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks [get]
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	subs, err := h.repo.ListWebhooks(c.Request.Context())
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve webhooks")
+		return
+	}
+	c.JSON(http.StatusOK, subs)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook subscription
+// @Description Returns a webhook subscription without its secret. Requires the "admin" role.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id} [get]
+func (h *Handler) GetWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	sub, err := h.repo.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		HandleRepositoryError(c, err, "Webhook not found", "Failed to retrieve webhook")
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Replaces the URL and event types of a subscription. A secret in the body rotates
+// @Description the signing secret; active pauses or resumes deliveries (pending ones wait while
+// @Description paused). Requires the "admin" role.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param webhook body models.UpdateWebhookRequest true "Subscription data"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id} [put]
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	sub, err := h.repo.GetWebhook(ctx, id)
+	if err != nil {
+		HandleRepositoryError(c, err, "Webhook not found", "Failed to update webhook")
+		return
+	}
+
+	sub.URL = req.URL
+	sub.EventTypes = req.EventTypes
+	if req.Secret != "" {
+		sub.Secret = req.Secret
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+	if err := h.repo.UpdateWebhook(ctx, sub); err != nil {
+		HandleRepositoryError(c, err, "Webhook not found", "Failed to update webhook")
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Deletes a subscription with its deliveries and their history. Requires the "admin" role.
+// @Tags Webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	if err := h.repo.DeleteWebhook(c.Request.Context(), id); err != nil {
+		HandleRepositoryError(c, err, "Webhook not found", "Failed to delete webhook")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Returns the deliveries of a subscription, newest first, with their status,
+// @Description attempt count and last error. Requires the "admin" role.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of deliveries to skip"
+// @Success 200 {object} models.WebhookDeliveryPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return
+	}
+
+	var query models.ListWebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		RespondBindingError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, err := h.repo.GetWebhook(ctx, id); err != nil {
+		HandleRepositoryError(c, err, "Webhook not found", "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	page, err := h.repo.ListWebhookDeliveries(ctx, id, query.Status, query.Limit, query.Offset)
+	if err != nil {
+		LogAndRespondError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetWebhookDelivery godoc
+// @Summary Get a webhook delivery
+// @Description Returns a delivery with the history of its attempts, newest first.
+// @Description Requires the "admin" role.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id}/deliveries/{deliveryId} [get]
+func (h *Handler) GetWebhookDelivery(c *gin.Context) {
+	id, deliveryID, ok := webhookDeliveryParams(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.repo.GetWebhookDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		HandleRepositoryError(c, err, "Delivery not found", "Failed to retrieve webhook delivery")
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// RedeliverWebhook godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queues a delivery to be sent again as soon as possible with a fresh set of
+// @Description attempts, including succeeded and dead ones. Requires the "admin" role.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *Handler) RedeliverWebhook(c *gin.Context) {
+	id, deliveryID, ok := webhookDeliveryParams(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.repo.RedeliverWebhookDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		HandleRepositoryError(c, err, "Delivery not found", "Failed to redeliver webhook delivery")
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// webhookDeliveryParams parses the subscription and delivery IDs of a delivery route,
+// responding with 400 if either is invalid
+func webhookDeliveryParams(c *gin.Context) (id, deliveryID int64, ok bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid ID format")
+		return 0, 0, false
+	}
+	deliveryID, err = strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		RespondError(c, problem.CodeInvalidID, "Invalid delivery ID format")
+		return 0, 0, false
+	}
+	return id, deliveryID, true
+}
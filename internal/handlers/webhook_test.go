@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/repository"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// webhookRepo stores subscriptions in memory
+type webhookRepo struct {
+	repository.Repository
+	subs map[int64]*models.WebhookSubscription
+}
+
+func (r *webhookRepo) CreateWebhook(_ context.Context, sub *models.WebhookSubscription) error {
+	sub.ID = int64(len(r.subs) + 1)
+	stored := *sub
+	r.subs[sub.ID] = &stored
+	return nil
+}
+
+func (r *webhookRepo) GetWebhook(_ context.Context, id int64) (*models.WebhookSubscription, error) {
+	sub, ok := r.subs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *sub
+	return &found, nil
+}
+
+func (r *webhookRepo) UpdateWebhook(_ context.Context, sub *models.WebhookSubscription) error {
+	stored := *sub
+	r.subs[sub.ID] = &stored
+	return nil
+}
+
+// performWebhook sends a JSON request to the webhook routes
+func performWebhook(repo *webhookRepo, method, target, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{repo: repo}
+	router := gin.New()
+	router.POST("/webhooks", h.CreateWebhook)
+	router.GET("/webhooks/:id", h.GetWebhook)
+	router.PUT("/webhooks/:id", h.UpdateWebhook)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// =============================================================================
+// Webhook Tests
+// =============================================================================
+
+func TestCreateWebhook_GeneratesSecretShownOnce(t *testing.T) {
+	repo := &webhookRepo{subs: map[int64]*models.WebhookSubscription{}}
+
+	w := performWebhook(repo, http.MethodPost, "/webhooks",
+		`{"url": "https://hooks.example.com/items", "event_types": ["ItemCreated", "ItemDeleted"]}`)
+
+	var created models.CreateWebhookResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(created.Secret, "whsec_") || repo.subs[1].Secret != created.Secret || !repo.subs[1].Active {
+		t.Errorf("created = %+v, stored = %+v, want active with a generated secret", created, repo.subs[1])
+	}
+
+	w = performWebhook(repo, http.MethodGet, "/webhooks/1", "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), created.Secret) {
+		t.Errorf("GET status = %d, body = %s, want 200 without the secret", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateWebhook_RejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "unknown event type", body: `{"url": "https://x.example.com", "event_types": ["ItemRenamed"]}`},
+		{name: "no event types", body: `{"url": "https://x.example.com", "event_types": []}`},
+		{name: "not an http URL", body: `{"url": "ftp://x.example.com", "event_types": ["ItemCreated"]}`},
+		{name: "short secret", body: `{"url": "https://x.example.com", "event_types": ["ItemCreated"], "secret": "short"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &webhookRepo{subs: map[int64]*models.WebhookSubscription{}}
+			w := performWebhook(repo, http.MethodPost, "/webhooks", tt.body)
+			if w.Code != http.StatusBadRequest || len(repo.subs) != 0 {
+				t.Errorf("status = %d, stored = %d, want 400 without a subscription", w.Code, len(repo.subs))
+			}
+		})
+	}
+}
+
+func TestUpdateWebhook_KeepsSecretAndActiveUnlessGiven(t *testing.T) {
+	repo := &webhookRepo{subs: map[int64]*models.WebhookSubscription{
+		1: {ID: 1, URL: "https://old.example.com", EventTypes: []string{"ItemCreated"}, Secret: "whsec_original", Active: false},
+	}}
+
+	w := performWebhook(repo, http.MethodPut, "/webhooks/1",
+		`{"url": "https://new.example.com", "event_types": ["ItemUpdated"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if sub := repo.subs[1]; sub.URL != "https://new.example.com" || sub.Secret != "whsec_original" || sub.Active {
+		t.Errorf("stored = %+v, want new URL with the same secret, still paused", sub)
+	}
+
+	performWebhook(repo, http.MethodPut, "/webhooks/1",
+		`{"url": "https://new.example.com", "event_types": ["ItemUpdated"], "secret": "rotated-secret-value", "active": true}`)
+	if sub := repo.subs[1]; sub.Secret != "rotated-secret-value" || !sub.Active {
+		t.Errorf("stored = %+v, want rotated secret and active", sub)
+	}
+
+	if w := performWebhook(repo, http.MethodPut, "/webhooks/9", `{"url": "https://x.example.com", "event_types": ["ItemUpdated"]}`); w.Code != http.StatusNotFound {
+		t.Errorf("unknown webhook status = %d, want 404", w.Code)
+	}
+}
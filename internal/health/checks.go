@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// Pinger is a connection pool such as *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// MigrationState reports pending migrations, see migrate.Migrator
+type MigrationState interface {
+	Pending(ctx context.Context) (int, error)
+}
+
+// Ping checks that the database answers
+func Ping(db Pinger) Checker {
+	return CheckerFunc(db.PingContext)
+}
+
+// Migrations checks that all known migrations have been applied, so the
+// instance does not serve traffic against an older schema
+func Migrations(state MigrationState) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		pending, err := state.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d migrations pending", pending)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,172 @@
+// Package health runs the dependency checks behind the liveness, readiness
+// and startup probes.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statuses of probes and checks
+const (
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Probe is a set of probes a check belongs to
+type Probe uint8
+
+// Probes
+const (
+	Live    Probe = 1 << iota // The process works; failing restarts it, so avoid external dependencies
+	Ready                     // The instance can serve traffic
+	Startup                   // The instance has finished starting; passes for good once it has passed
+)
+
+// Checker checks one dependency; a nil error means it is healthy
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to a Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Report is the outcome of a probe
+type Report struct {
+	Status       string            `json:"status"`
+	ShuttingDown bool              `json:"shutting_down,omitempty"`
+	Checks       map[string]Result `json:"checks"`
+}
+
+// Healthy reports whether the probe passed
+func (r Report) Healthy() bool {
+	return r.Status == StatusHealthy
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	LatencyMs float64   `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// check is a registered checker with its cached result
+type check struct {
+	name    string
+	checker Checker
+	probes  Probe
+
+	mu      sync.Mutex // Held while checking, so concurrent probes share one run
+	result  Result
+	expires time.Time
+}
+
+// Registry holds the registered checks. Results are cached for a while so
+// frequent probes from several sources do not load the dependencies.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu      sync.RWMutex
+	checks  []*check
+	started atomic.Bool // Startup has passed
+	closing atomic.Bool // Shutdown has begun
+}
+
+// NewRegistry returns an empty registry. Each check may take up to timeout
+// and its result is reused for cacheTTL.
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{timeout: timeout, cacheTTL: cacheTTL, now: time.Now}
+}
+
+// Register adds a check to the given probes, e.g. Ready|Startup
+func (r *Registry) Register(name string, checker Checker, probes Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, &check{name: name, checker: checker, probes: probes})
+}
+
+// Shutdown makes readiness fail from now on, so load balancers stop sending
+// traffic while in-flight requests finish
+func (r *Registry) Shutdown() {
+	r.closing.Store(true)
+}
+
+// Run runs the checks of a probe concurrently and reports their results.
+// The probe is healthy if all of its checks pass.
+func (r *Registry) Run(ctx context.Context, probe Probe) Report {
+	if probe == Ready && r.closing.Load() {
+		return Report{Status: StatusUnhealthy, ShuttingDown: true, Checks: map[string]Result{}}
+	}
+
+	r.mu.RLock()
+	var checks []*check
+	for _, c := range r.checks {
+		if c.probes&probe != 0 && (probe != Startup || !r.started.Load()) {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.check(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusHealthy, Checks: make(map[string]Result, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusHealthy {
+			report.Status = StatusUnhealthy
+		}
+	}
+	if probe == Startup && report.Healthy() {
+		r.started.Store(true)
+	}
+	return report
+}
+
+// check returns the cached result of c, running it if the result has expired
+func (r *Registry) check(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if r.now().Before(c.expires) {
+		return c.result
+	}
+
+	// The result is shared, so a canceled probe request must not fail it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	start := r.now()
+	err := c.checker.Check(ctx)
+	result := Result{
+		Status:    StatusHealthy,
+		LatencyMs: float64(r.now().Sub(start).Microseconds()) / 1000,
+		CheckedAt: start.UTC(),
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+
+	c.result = result
+	c.expires = start.Add(r.cacheTTL)
+	return result
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// countingChecker counts its calls and returns err
+type countingChecker struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (c *countingChecker) Check(context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+// pendingMigrations is a MigrationState with a fixed result
+type pendingMigrations struct {
+	pending int
+	err     error
+}
+
+func (p pendingMigrations) Pending(context.Context) (int, error) {
+	return p.pending, p.err
+}
+
+// =============================================================================
+// Registry Tests
+// =============================================================================
+
+func TestRegistry_ReportsEachCheck(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.Register("database", &countingChecker{}, Ready)
+	r.Register("queue", &countingChecker{err: errors.New("connection refused")}, Ready)
+	r.Register("disk", &countingChecker{}, Live)
+
+	report := r.Run(context.Background(), Ready)
+
+	if report.Healthy() || len(report.Checks) != 2 {
+		t.Fatalf("Run(Ready) = %+v, want unhealthy with 2 checks", report)
+	}
+	if got := report.Checks["database"]; got.Status != StatusHealthy || got.CheckedAt.IsZero() {
+		t.Errorf("database = %+v, want healthy with check time", got)
+	}
+	if got := report.Checks["queue"]; got.Status != StatusUnhealthy || got.Error != "connection refused" {
+		t.Errorf("queue = %+v, want unhealthy with error", got)
+	}
+	if live := r.Run(context.Background(), Live); !live.Healthy() || len(live.Checks) != 1 {
+		t.Errorf("Run(Live) = %+v, want only the healthy disk check", live)
+	}
+}
+
+func TestRegistry_CachesResults(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRegistry(time.Second, 5*time.Second)
+	r.now = func() time.Time { return now }
+	checker := &countingChecker{}
+	r.Register("database", checker, Ready|Startup)
+
+	r.Run(context.Background(), Ready)
+	r.Run(context.Background(), Startup)
+	now = now.Add(4 * time.Second)
+	r.Run(context.Background(), Ready)
+	if got := checker.calls.Load(); got != 1 {
+		t.Errorf("checks within the cache TTL = %d, want 1", got)
+	}
+
+	now = now.Add(time.Second)
+	r.Run(context.Background(), Ready)
+	if got := checker.calls.Load(); got != 2 {
+		t.Errorf("checks after the cache TTL = %d, want 2", got)
+	}
+}
+
+func TestRegistry_TimesOutSlowChecks(t *testing.T) {
+	r := NewRegistry(10*time.Millisecond, 0)
+	r.Register("database", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), Ready)
+
+	// A canceled probe request still gets a real result to share with others
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := r.Run(ctx, Ready)
+
+	got := report.Checks["database"]
+	if got.Status != StatusUnhealthy || got.Error != context.DeadlineExceeded.Error() || got.LatencyMs < 10 {
+		t.Errorf("database = %+v, want a timeout after at least 10ms", got)
+	}
+}
+
+func TestRegistry_StartupPassesForGood(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	checker := &countingChecker{err: errors.New("not yet")}
+	r.Register("migrations", checker, Startup)
+
+	if r.Run(context.Background(), Startup).Healthy() {
+		t.Fatal("startup should fail while its check fails")
+	}
+	checker.err = nil
+	if !r.Run(context.Background(), Startup).Healthy() {
+		t.Fatal("startup should pass once its check passes")
+	}
+	checker.err = errors.New("down again")
+	if !r.Run(context.Background(), Startup).Healthy() || checker.calls.Load() != 2 {
+		t.Errorf("startup should keep passing without checking, calls = %d", checker.calls.Load())
+	}
+}
+
+func TestRegistry_ShutdownFailsReadinessOnly(t *testing.T) {
+	r := NewRegistry(time.Second, 0)
+	r.Register("database", &countingChecker{}, Live|Ready)
+
+	r.Shutdown()
+
+	if ready := r.Run(context.Background(), Ready); ready.Healthy() || !ready.ShuttingDown {
+		t.Errorf("Run(Ready) = %+v, want unhealthy while shutting down", ready)
+	}
+	if !r.Run(context.Background(), Live).Healthy() {
+		t.Error("liveness should pass while shutting down")
+	}
+}
+
+// =============================================================================
+// Checker Tests
+// =============================================================================
+
+func TestMigrations_FailsWhilePending(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   pendingMigrations
+		wantErr string
+	}{
+		{name: "up to date", state: pendingMigrations{}},
+		{name: "pending", state: pendingMigrations{pending: 2}, wantErr: "2 migrations pending"},
+		{name: "unreadable", state: pendingMigrations{err: errors.New("no schema_migrations")}, wantErr: "no schema_migrations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Migrations(tt.state).Check(context.Background())
+
+			if (err == nil) != (tt.wantErr == "") || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Package i18n translates client-facing messages into the locale negotiated
+// from the Accept-Language header. Messages are keyed by their English text,
+// with {0}, {1}, ... for parameters; keys without a translation are shown in
+// English.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// DefaultLocale is used when no requested locale is supported
+const DefaultLocale = "en"
+
+// universal holds a translator per supported locale, English being the fallback
+var universal = load()
+
+type contextKey struct{}
+
+// load registers the catalogs of all supported locales, panicking on malformed
+// entries so a broken catalog fails at startup (and in tests) instead of per request
+func load() *ut.UniversalTranslator {
+	fallback := en.New()
+	uni := ut.New(fallback, fallback, de.New())
+
+	for locale, messages := range translations {
+		trans, found := uni.GetTranslator(locale)
+		if !found {
+			panic(fmt.Sprintf("i18n: unsupported locale %q", locale))
+		}
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(fmt.Sprintf("i18n: %v", err))
+			}
+		}
+	}
+	for locale, messages := range plurals {
+		trans, _ := uni.GetTranslator(locale)
+		for key, forms := range messages {
+			for rule, text := range forms {
+				if err := trans.AddCardinal(key, text, rule, false); err != nil {
+					panic(fmt.Sprintf("i18n: %v", err))
+				}
+			}
+		}
+	}
+	if err := uni.VerifyTranslations(); err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+	return uni
+}
+
+// Middleware returns a middleware negotiating the locale of each request from
+// its Accept-Language header. The translator is stored in the request context
+// (see FromContext) and its locale returned in the Content-Language header.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trans := Negotiate(c.GetHeader("Accept-Language"))
+
+		c.Request = c.Request.WithContext(WithTranslator(c.Request.Context(), trans))
+		c.Header("Content-Language", trans.Locale())
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// Negotiate returns the translator of the most preferred supported locale of an
+// Accept-Language header, e.g. "de-AT,de;q=0.9,en;q=0.5". Regional variants fall
+// back to their language; without a match the English translator is returned.
+func Negotiate(acceptLanguage string) ut.Translator {
+	trans, _ := universal.FindTranslator(preferredLocales(acceptLanguage)...)
+	return trans
+}
+
+// preferredLocales lists the locales of an Accept-Language header by descending
+// quality as locale names ("de_AT"), each followed by its language ("de")
+func preferredLocales(acceptLanguage string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if tag == "" || tag == "*" || quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag, quality})
+	}
+	slices.SortStableFunc(tags, func(a, b weighted) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var names []string
+	for _, t := range tags {
+		name := strings.ReplaceAll(t.tag, "-", "_")
+		language, region, found := strings.Cut(name, "_")
+		language = strings.ToLower(language)
+		if found {
+			names = append(names, language+"_"+strings.ToUpper(region))
+		}
+		names = append(names, language)
+	}
+	return names
+}
+
+// WithTranslator returns a context translating messages with trans
+func WithTranslator(ctx context.Context, trans ut.Translator) context.Context {
+	return context.WithValue(ctx, contextKey{}, trans)
+}
+
+// FromContext returns the translator stored by WithTranslator, or the English
+// translator outside a request
+func FromContext(ctx context.Context) ut.Translator {
+	if trans, ok := ctx.Value(contextKey{}).(ut.Translator); ok {
+		return trans
+	}
+	return universal.GetFallback()
+}
+
+// T translates the message key with params into the locale of ctx
+func T(ctx context.Context, key string, params ...string) string {
+	return Translate(FromContext(ctx), key, params...)
+}
+
+// Translate translates the message key with params. Keys missing from the
+// catalog of trans are returned in English with their parameters filled in.
+func Translate(trans ut.Translator, key string, params ...string) string {
+	if text, err := trans.T(key, params...); err == nil {
+		return text
+	}
+	for i, param := range params {
+		key = strings.ReplaceAll(key, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return key
+}
+
+// Plural translates a key with plural forms for count, e.g. "{0} items"
+// giving "1 item" or "5 items" in English. count is a decimal number.
+func Plural(ctx context.Context, key, count string) string {
+	trans := FromContext(ctx)
+	if n, err := strconv.ParseFloat(count, 64); err == nil {
+		if text, err := trans.C(key, n, 0, count); err == nil {
+			return text
+		}
+	}
+	return strings.ReplaceAll(key, "{0}", count)
+}
+
+// pluralForms lists the text of a key for each plural rule of a locale
+type pluralForms map[locales.PluralRule]string
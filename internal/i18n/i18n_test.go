@@ -0,0 +1,141 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// Negotiation Tests
+// =============================================================================
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "missing", acceptLanguage: "", want: "en"},
+		{name: "exact", acceptLanguage: "de", want: "de"},
+		{name: "region falls back to language", acceptLanguage: "de-AT", want: "de"},
+		{name: "case insensitive", acceptLanguage: "DE-at", want: "de"},
+		{name: "first supported", acceptLanguage: "fr, de, en", want: "de"},
+		{name: "highest quality", acceptLanguage: "en;q=0.5, de;q=0.9", want: "de"},
+		{name: "zero quality excluded", acceptLanguage: "de;q=0, en;q=0.1", want: "en"},
+		{name: "wildcard", acceptLanguage: "*", want: "en"},
+		{name: "unsupported", acceptLanguage: "fr-FR, ja", want: "en"},
+		{name: "malformed quality skipped", acceptLanguage: "de;q=abc", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.acceptLanguage).Locale(); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_StoresTranslatorAndSetsContentLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var detail string
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/items", func(c *gin.Context) {
+		detail = T(c.Request.Context(), "Item not found")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Language"); got != "de" {
+		t.Errorf("Content-Language = %q, want de", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Language" {
+		t.Errorf("Vary = %q, want Accept-Language", got)
+	}
+	if detail != "Eintrag nicht gefunden" {
+		t.Errorf("detail = %q, want German translation", detail)
+	}
+}
+
+// =============================================================================
+// Translation Tests
+// =============================================================================
+
+func TestT(t *testing.T) {
+	german := WithTranslator(context.Background(), Negotiate("de"))
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		key    string
+		params []string
+		want   string
+	}{
+		{name: "english outside request", ctx: context.Background(), key: "Item not found", want: "Item not found"},
+		{name: "translated", ctx: german, key: "Item not found", want: "Eintrag nicht gefunden"},
+		{name: "parameters", ctx: german, key: "Line {0} is longer than {1} bytes", params: []string{"3", "1024"}, want: "Zeile 3 ist länger als 1024 Bytes"},
+		{name: "missing key in english", ctx: german, key: "No such {0}", params: []string{"message"}, want: "No such message"},
+		{name: "english parameters", ctx: context.Background(), key: "{0} must be at most {1}", params: []string{"limit", "100"}, want: "limit must be at most 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.ctx, tt.key, tt.params...); got != tt.want {
+				t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlural(t *testing.T) {
+	german := WithTranslator(context.Background(), Negotiate("de"))
+
+	tests := []struct {
+		ctx   context.Context
+		key   string
+		count string
+		want  string
+	}{
+		{ctx: context.Background(), key: "{0} characters", count: "1", want: "1 character"},
+		{ctx: context.Background(), key: "{0} characters", count: "16", want: "16 characters"},
+		{ctx: context.Background(), key: "{0} items", count: "1", want: "1 item"},
+		{ctx: german, key: "{0} items", count: "1", want: "1 Eintrag"},
+		{ctx: german, key: "{0} items", count: "100", want: "100 Einträge"},
+		{ctx: german, key: "{0} characters", count: "1", want: "1 Zeichen"},
+		{ctx: context.Background(), key: "{0} widgets", count: "2", want: "2 widgets"},
+		{ctx: context.Background(), key: "{0} items", count: "many", want: "many items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Plural(tt.ctx, tt.key, tt.count); got != tt.want {
+				t.Errorf("Plural(%q, %q) = %q, want %q", tt.key, tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_KeepsPlaceholdersInOrder(t *testing.T) {
+	placeholder := regexp.MustCompile(`\{\d+\}`)
+
+	for locale, messages := range translations {
+		for key, text := range messages {
+			want := placeholder.FindAllString(key, -1)
+			if got := placeholder.FindAllString(text, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has placeholders %v, want %v of %q", locale, text, got, want, key)
+			}
+		}
+	}
+}
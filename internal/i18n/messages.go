@@ -0,0 +1,154 @@
+package i18n
+
+import "github.com/go-playground/locales"
+
+// translations maps each supported locale, other than English, to the
+// translations of message keys. Translations keep the parameters of their
+// key, in the same order.
+var translations = map[string]map[string]string{
+	"de": {
+		// Problem titles
+		"Malformed request":           "Fehlerhafte Anfrage",
+		"Validation failed":           "Validierung fehlgeschlagen",
+		"Invalid ID":                  "Ungültige ID",
+		"Authentication required":     "Authentifizierung erforderlich",
+		"Invalid credentials":         "Ungültige Anmeldedaten",
+		"Invalid token":               "Ungültiges Token",
+		"Token expired":               "Token abgelaufen",
+		"Invalid API key":             "Ungültiger API-Schlüssel",
+		"Insufficient permissions":    "Unzureichende Berechtigungen",
+		"Resource not found":          "Ressource nicht gefunden",
+		"Patch cannot be applied":     "Patch kann nicht angewendet werden",
+		"Version mismatch":            "Version stimmt nicht überein",
+		"Payload too large":           "Nutzdaten zu groß",
+		"Unsupported media type":      "Nicht unterstützter Medientyp",
+		"Patched resource is invalid": "Gepatchte Ressource ist ungültig",
+		"Precondition required":       "Vorbedingung erforderlich",
+		"Internal server error":       "Interner Serverfehler",
+
+		// Request binding and validation
+		"Request body is empty":                     "Anfragetext ist leer",
+		"Request body is not valid JSON":            "Anfragetext ist kein gültiges JSON",
+		"Request body or query could not be parsed": "Anfragetext oder Abfrage konnte nicht gelesen werden",
+		"{0} is required":                           "{0} ist erforderlich",
+		"{0} must not be set":                       "{0} darf nicht gesetzt sein",
+		"{0} must be at least {1}":                  "{0} muss mindestens {1} sein",
+		"{0} must be at most {1}":                   "{0} darf höchstens {1} sein",
+		"{0} must be one of: {1}":                   "{0} muss einer der folgenden Werte sein: {1}",
+		"{0} must be a valid URL":                   "{0} muss eine gültige URL sein",
+		"{0} must not contain any of {1}":           "{0} darf keines der Zeichen {1} enthalten",
+		"{0} does not satisfy {1}":                  "{0} erfüllt {1} nicht",
+		"{0} must be {1}":                           "{0} muss {1} sein",
+		"{0} must be in the future":                 "{0} muss in der Zukunft liegen",
+		"a string":                                  "eine Zeichenkette",
+		"a boolean":                                 "ein Wahrheitswert",
+		"a number":                                  "eine Zahl",
+		"an array":                                  "ein Array",
+		"an object":                                 "ein Objekt",
+
+		// Authentication and authorization
+		"Missing or malformed bearer token":   "Bearer-Token fehlt oder ist fehlerhaft",
+		"Token has expired":                   "Token ist abgelaufen",
+		"Token is not valid yet":              "Token ist noch nicht gültig",
+		"Token issuer is not accepted":        "Token-Aussteller wird nicht akzeptiert",
+		"Token audience is not accepted":      "Token-Zielgruppe wird nicht akzeptiert",
+		"API key has expired or been revoked": "API-Schlüssel ist abgelaufen oder wurde widerrufen",
+		"Failed to authenticate":              "Authentifizierung fehlgeschlagen",
+		"Requires one of roles: {0}":          "Erfordert eine der Rollen: {0}",
+		"Requires scopes: {0}":                "Erfordert die Scopes: {0}",
+		"Invalid username or password":        "Ungültiger Benutzername oder ungültiges Passwort",
+		"Invalid refresh token":               "Ungültiges Refresh-Token",
+		"Refresh token has expired":           "Refresh-Token ist abgelaufen",
+		"Failed to issue token":               "Token konnte nicht ausgestellt werden",
+		"Failed to refresh token":             "Token konnte nicht erneuert werden",
+		"Failed to revoke token":              "Token konnte nicht widerrufen werden",
+
+		// Items
+		"Route not found":                                      "Route nicht gefunden",
+		"Invalid ID format":                                    "Ungültiges ID-Format",
+		"Item not found":                                       "Eintrag nicht gefunden",
+		"Item not found in trash":                              "Eintrag nicht im Papierkorb gefunden",
+		`cannot sort by "{0}" (allowed: {1})`:                  `Sortierung nach "{0}" nicht möglich (erlaubt: {1})`,
+		`duplicate sort field "{0}"`:                           `Sortierfeld "{0}" ist doppelt angegeben`,
+		"invalid cursor":                                       "Ungültiger cursor",
+		"cursor and offset cannot be combined":                 "cursor und offset können nicht kombiniert werden",
+		"cursor requires sorting by created_at or -created_at": "cursor erfordert Sortierung nach created_at oder -created_at",
+		"If-Match header is required":                          "If-Match-Header ist erforderlich",
+		"If-Match does not match the current version":          "If-Match entspricht nicht der aktuellen Version",
+		"Failed to create item":                                "Eintrag konnte nicht erstellt werden",
+		"Failed to retrieve item":                              "Eintrag konnte nicht abgerufen werden",
+		"Failed to retrieve items":                             "Einträge konnten nicht abgerufen werden",
+		"Failed to search items":                               "Einträge konnten nicht durchsucht werden",
+		"Failed to update item":                                "Eintrag konnte nicht aktualisiert werden",
+		"Failed to patch item":                                 "Eintrag konnte nicht gepatcht werden",
+		"Failed to delete item":                                "Eintrag konnte nicht gelöscht werden",
+		"Failed to restore item":                               "Eintrag konnte nicht wiederhergestellt werden",
+		"Failed to purge item":                                 "Eintrag konnte nicht endgültig gelöscht werden",
+		"Failed to retrieve deleted items":                     "Gelöschte Einträge konnten nicht abgerufen werden",
+		"Failed to retrieve item history":                      "Verlauf des Eintrags konnte nicht abgerufen werden",
+		"Failed to retrieve audit events":                      "Audit-Ereignisse konnten nicht abgerufen werden",
+
+		// Patch
+		"Content-Type must be {0} or {1}": "Content-Type muss {0} oder {1} sein",
+		"Patch document is too large":     "Patch-Dokument ist zu groß",
+		"Failed to read request body":     "Anfragetext konnte nicht gelesen werden",
+		"Invalid merge patch: {0}":        "Ungültiger Merge-Patch: {0}",
+		"Invalid JSON Patch: {0}":         "Ungültiger JSON-Patch: {0}",
+		"Patch cannot be applied: {0}":    "Patch kann nicht angewendet werden: {0}",
+		"Patched item is invalid: {0}":    "Gepatchter Eintrag ist ungültig: {0}",
+
+		// Bulk operations
+		"Version is required":                          "Version ist erforderlich",
+		"Duplicate item ID {0}":                        "Doppelte Eintrags-ID {0}",
+		"Version does not match the current version":   "Version entspricht nicht der aktuellen Version",
+		"Not applied because another operation failed": "Nicht angewendet, da eine andere Operation fehlgeschlagen ist",
+		"Failed to apply operation":                    "Operation konnte nicht angewendet werden",
+		"Failed to apply bulk operations":              "Massenoperationen konnten nicht angewendet werden",
+
+		// Import and export
+		"Import is larger than {0} bytes":         "Import ist größer als {0} Bytes",
+		"CSV header row is missing":               "CSV-Kopfzeile fehlt",
+		`CSV header must contain a "name" column`: `CSV-Kopfzeile muss eine Spalte "name" enthalten`,
+		"Invalid CSV header: {0}":                 "Ungültige CSV-Kopfzeile: {0}",
+		"Invalid JSON: {0}":                       "Ungültiges JSON: {0}",
+		"Line {0} is longer than {1} bytes":       "Zeile {0} ist länger als {1} Bytes",
+		"Failed to import items":                  "Einträge konnten nicht importiert werden",
+		"Failed to export items":                  "Einträge konnten nicht exportiert werden",
+
+		// Events
+		"Invalid Last-Event-ID": "Ungültige Last-Event-ID",
+
+		// API keys
+		"API key not found":           "API-Schlüssel nicht gefunden",
+		"Failed to create API key":    "API-Schlüssel konnte nicht erstellt werden",
+		"Failed to retrieve API keys": "API-Schlüssel konnten nicht abgerufen werden",
+		"Failed to revoke API key":    "API-Schlüssel konnte nicht widerrufen werden",
+
+		// Webhooks
+		"Webhook not found":                     "Webhook nicht gefunden",
+		"Delivery not found":                    "Zustellung nicht gefunden",
+		"Invalid delivery ID format":            "Ungültiges Format der Zustellungs-ID",
+		"Failed to create webhook":              "Webhook konnte nicht erstellt werden",
+		"Failed to retrieve webhook":            "Webhook konnte nicht abgerufen werden",
+		"Failed to retrieve webhooks":           "Webhooks konnten nicht abgerufen werden",
+		"Failed to update webhook":              "Webhook konnte nicht aktualisiert werden",
+		"Failed to delete webhook":              "Webhook konnte nicht gelöscht werden",
+		"Failed to retrieve webhook deliveries": "Webhook-Zustellungen konnten nicht abgerufen werden",
+		"Failed to retrieve webhook delivery":   "Webhook-Zustellung konnte nicht abgerufen werden",
+		"Failed to redeliver webhook delivery":  "Webhook-Zustellung konnte nicht erneut zugestellt werden",
+	},
+}
+
+// plurals maps each supported locale to the plural forms of message keys whose
+// wording depends on a count, which fills in {0}. Every locale needs a form
+// for each of its plural rules.
+var plurals = map[string]map[string]pluralForms{
+	"en": {
+		"{0} characters": {locales.PluralRuleOne: "{0} character", locales.PluralRuleOther: "{0} characters"},
+		"{0} items":      {locales.PluralRuleOne: "{0} item", locales.PluralRuleOther: "{0} items"},
+	},
+	"de": {
+		"{0} characters": {locales.PluralRuleOne: "{0} Zeichen", locales.PluralRuleOther: "{0} Zeichen"},
+		"{0} items":      {locales.PluralRuleOne: "{0} Eintrag", locales.PluralRuleOther: "{0} Einträge"},
+	},
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// outboxLease is how long claimed events are reserved for one relay. A batch that
+// takes longer is cut short; its unpublished events become due when the lease ends.
+const outboxLease = time.Minute
+
+// retryMinBackoff is the delay before the first retry of a failed event or delivery
+const retryMinBackoff = time.Second
+
+// OutboxStore claims outbox events and records the outcome of publishing them
+type OutboxStore interface {
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	DeleteOutboxEvent(ctx context.Context, id int64) error
+	RescheduleOutboxEvent(ctx context.Context, id int64, next time.Time, lastError string) error
+}
+
+// OutboxOptions controls RunOutboxRelay
+type OutboxOptions struct {
+	PollInterval time.Duration // How often to check for due events
+	BatchSize    int           // Events claimed at a time
+	MaxBackoff   time.Duration // Longest delay between retries of a failed event
+}
+
+// RunOutboxRelay publishes due outbox events every PollInterval until ctx is done,
+// draining full batches without waiting. Published events are deleted; failed ones
+// are retried with exponential backoff. Delivery is at least once: an event is sent
+// again if it cannot be deleted after publishing or the relay stops mid-publish.
+func RunOutboxRelay(ctx context.Context, store OutboxStore, publisher events.Publisher, opts OutboxOptions) {
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			if relayOutbox(ctx, store, publisher, opts) < opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayOutbox publishes one batch of due events and returns how many were claimed
+func relayOutbox(ctx context.Context, store OutboxStore, publisher events.Publisher, opts OutboxOptions) int {
+	claimed, err := store.ClaimOutboxEvents(ctx, opts.BatchSize, outboxLease)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Failed to claim outbox events", "error", err.Error())
+		}
+		return 0
+	}
+
+	leaseCtx, cancel := context.WithTimeout(ctx, outboxLease)
+	defer cancel()
+
+	for _, row := range claimed {
+		if leaseCtx.Err() != nil {
+			break // The rest are retried once the lease ends
+		}
+
+		err := publisher.Publish(leaseCtx, outboxEvent(row))
+		if err != nil {
+			if leaseCtx.Err() != nil {
+				break
+			}
+			next := time.Now().Add(retryBackoff(row.Attempts, opts.MaxBackoff))
+			slog.Warn("Failed to publish outbox event",
+				"id", row.ID, "type", row.EventType, "attempts", row.Attempts,
+				"next_attempt_at", next, "error", err.Error())
+			if err := store.RescheduleOutboxEvent(ctx, row.ID, next, err.Error()); err != nil {
+				slog.Warn("Failed to reschedule outbox event", "id", row.ID, "error", err.Error())
+			}
+			continue
+		}
+
+		if err := store.DeleteOutboxEvent(ctx, row.ID); err != nil {
+			slog.Warn("Failed to delete published outbox event", "id", row.ID, "error", err.Error())
+		}
+	}
+	return len(claimed)
+}
+
+// outboxEvent converts an outbox row into the published event
+func outboxEvent(row models.OutboxEvent) events.Event {
+	return events.Event{
+		ID:            row.ID,
+		Type:          row.EventType,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		OccurredAt:    row.CreatedAt,
+		Data:          row.Payload,
+	}
+}
+
+// retryBackoff returns the delay after the given number of failed attempts:
+// retryMinBackoff doubled for each attempt after the first, capped at maxBackoff
+func retryBackoff(attempts int, maxBackoff time.Duration) time.Duration {
+	backoff := retryMinBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
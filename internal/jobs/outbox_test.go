@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// fakeOutboxStore hands out pending events once and records their outcomes
+type fakeOutboxStore struct {
+	mu          sync.Mutex
+	pending     []models.OutboxEvent
+	deleted     []int64
+	rescheduled map[int64]string
+	next        map[int64]time.Time
+}
+
+func newFakeOutboxStore(count int) *fakeOutboxStore {
+	s := &fakeOutboxStore{rescheduled: map[int64]string{}, next: map[int64]time.Time{}}
+	for i := 1; i <= count; i++ {
+		s.pending = append(s.pending, models.OutboxEvent{
+			ID:            int64(i),
+			EventType:     events.ItemCreated,
+			AggregateType: "item",
+			AggregateID:   int64(100 + i),
+			Payload:       json.RawMessage(`{"id":1}`),
+		})
+	}
+	return s
+}
+
+func (s *fakeOutboxStore) ClaimOutboxEvents(_ context.Context, limit int, _ time.Duration) ([]models.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := min(limit, len(s.pending))
+	claimed := s.pending[:n]
+	s.pending = s.pending[n:]
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+	return claimed, nil
+}
+
+func (s *fakeOutboxStore) DeleteOutboxEvent(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func (s *fakeOutboxStore) RescheduleOutboxEvent(_ context.Context, id int64, next time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rescheduled[id] = lastError
+	s.next[id] = next
+	return nil
+}
+
+var testOutboxOptions = OutboxOptions{PollInterval: time.Hour, BatchSize: 2, MaxBackoff: time.Minute}
+
+// =============================================================================
+// RunOutboxRelay Tests
+// =============================================================================
+
+func TestRunOutboxRelay_PublishesAndDeletesAllBatches(t *testing.T) {
+	store := newFakeOutboxStore(5)
+	publisher := &events.MemoryPublisher{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		RunOutboxRelay(ctx, store, publisher, testOutboxOptions)
+		close(done)
+	}()
+
+	// Full batches are drained without waiting for the hour-long poll interval
+	deadline := time.After(2 * time.Second)
+	for len(publisher.Events()) < 5 {
+		select {
+		case <-deadline:
+			t.Fatalf("published %d events, want 5", len(publisher.Events()))
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	cancel()
+	<-done
+
+	published := publisher.Events()
+	for i, event := range published {
+		if event.ID != int64(i+1) || event.Type != events.ItemCreated || event.AggregateID != int64(101+i) {
+			t.Errorf("event %d = %+v", i, event)
+		}
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.deleted) != 5 || len(store.rescheduled) != 0 {
+		t.Errorf("deleted = %v, rescheduled = %v, want all 5 deleted", store.deleted, store.rescheduled)
+	}
+}
+
+func TestRelayOutbox_ReschedulesFailedEvents(t *testing.T) {
+	store := newFakeOutboxStore(2)
+	store.pending[1].Attempts = 3 // Failed three times before
+	publisher := &events.MemoryPublisher{Fail: func(e events.Event) error {
+		if e.ID == 2 {
+			return errors.New("receiver down")
+		}
+		return nil
+	}}
+
+	before := time.Now()
+	claimed := relayOutbox(context.Background(), store, publisher, testOutboxOptions)
+
+	if claimed != 2 {
+		t.Errorf("claimed = %d, want 2", claimed)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != 1 {
+		t.Errorf("deleted = %v, want [1]", store.deleted)
+	}
+	if store.rescheduled[2] != "receiver down" {
+		t.Errorf("rescheduled = %v, want event 2 with its error", store.rescheduled)
+	}
+	// Fourth attempt failed: 1s doubled three times
+	if delay := store.next[2].Sub(before); delay < 8*time.Second || delay > 9*time.Second {
+		t.Errorf("retry delay = %v, want about 8s", delay)
+	}
+}
+
+func TestRetryBackoff_TableDriven(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 6, want: 32 * time.Second},
+		{attempts: 7, want: time.Minute},
+		{attempts: 1000, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := retryBackoff(tt.attempts, time.Minute); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package jobs contains background work that runs alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/audit"
+)
+
+// TrashStore permanently deletes items from the trash
+type TrashStore interface {
+	PurgeDeletedItems(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// RunTrashPurger permanently deletes items that have been in the trash longer
+// than retention, once at start and then every interval, until ctx is done.
+// Failed runs are logged and retried at the next interval.
+// Purges are audited as audit.SystemActor.
+func RunTrashPurger(ctx context.Context, store TrashStore, retention, interval time.Duration) {
+	ctx = audit.WithMetadata(ctx, audit.Metadata{Actor: audit.SystemActor})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purgeTrash(ctx, store, retention)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash runs a single purge of items deleted before now minus retention
+func purgeTrash(ctx context.Context, store TrashStore, retention time.Duration) {
+	purged, err := store.PurgeDeletedItems(ctx, time.Now().Add(-retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Failed to purge deleted items", "error", err.Error())
+		}
+		return
+	}
+	if purged > 0 {
+		slog.Info("Purged deleted items", "count", purged, "retention", retention.String())
+	}
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// fakeTrashStore records the cutoffs it was called with
+type fakeTrashStore struct {
+	mu      sync.Mutex
+	cutoffs []time.Time
+	err     error
+	called  chan struct{}
+}
+
+func newFakeTrashStore(err error) *fakeTrashStore {
+	return &fakeTrashStore{err: err, called: make(chan struct{}, 10)}
+}
+
+func (s *fakeTrashStore) PurgeDeletedItems(_ context.Context, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	s.cutoffs = append(s.cutoffs, cutoff)
+	s.mu.Unlock()
+	select {
+	case s.called <- struct{}{}:
+	default: // nobody waiting
+	}
+	return 1, s.err
+}
+
+// waitForCalls waits until the store has been called n times
+func (s *fakeTrashStore) waitForCalls(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.called:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("PurgeDeletedItems called %d times, want %d", i, n)
+		}
+	}
+}
+
+// =============================================================================
+// RunTrashPurger Tests
+// =============================================================================
+
+func TestRunTrashPurger_PurgesAtStartAndEveryInterval(t *testing.T) {
+	store := newFakeTrashStore(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	start := time.Now()
+	go func() {
+		RunTrashPurger(ctx, store, 24*time.Hour, 10*time.Millisecond)
+		close(done)
+	}()
+	store.waitForCalls(t, 3)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("RunTrashPurger did not stop after cancel")
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	wantCutoff := start.Add(-24 * time.Hour)
+	if first := store.cutoffs[0]; first.Before(wantCutoff) || first.After(wantCutoff.Add(time.Second)) {
+		t.Errorf("first cutoff = %v, want about %v", first, wantCutoff)
+	}
+}
+
+func TestRunTrashPurger_KeepsRunningAfterErrors(t *testing.T) {
+	store := newFakeTrashStore(errors.New("connection refused"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go RunTrashPurger(ctx, store, time.Hour, 10*time.Millisecond)
+
+	store.waitForCalls(t, 2)
+}
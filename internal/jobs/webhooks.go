@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/webhooks"
+)
+
+// webhookLease is how long claimed deliveries are reserved for one dispatcher.
+// A batch that takes longer is cut short; its unsent deliveries become due when the lease ends.
+const webhookLease = time.Minute
+
+// webhookBatchSize is how many deliveries are claimed at a time
+const webhookBatchSize = 50
+
+// WebhookStore claims due webhook deliveries and records their attempts
+type WebhookStore interface {
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+}
+
+// WebhookOptions controls RunWebhookDispatcher
+type WebhookOptions struct {
+	PollInterval time.Duration // How often to check for due deliveries
+	MaxAttempts  int           // Attempts before a delivery is marked dead
+	MaxBackoff   time.Duration // Longest delay between attempts
+}
+
+// RunWebhookDispatcher sends due webhook deliveries every PollInterval until ctx is done,
+// draining full batches without waiting. Every attempt is recorded; failed deliveries are
+// retried with exponential backoff and marked dead after MaxAttempts.
+func RunWebhookDispatcher(ctx context.Context, store WebhookStore, client *http.Client, opts WebhookOptions) {
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			if dispatchWebhooks(ctx, store, client, opts) < webhookBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchWebhooks sends one batch of due deliveries and returns how many were claimed
+func dispatchWebhooks(ctx context.Context, store WebhookStore, client *http.Client, opts WebhookOptions) int {
+	claimed, err := store.ClaimWebhookDeliveries(ctx, webhookBatchSize, webhookLease)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Failed to claim webhook deliveries", "error", err.Error())
+		}
+		return 0
+	}
+
+	leaseCtx, cancel := context.WithTimeout(ctx, webhookLease)
+	defer cancel()
+
+	for i := range claimed {
+		if leaseCtx.Err() != nil {
+			break // The rest are retried once the lease ends
+		}
+
+		delivery := &claimed[i]
+		start := time.Now()
+		status, err := webhooks.Send(leaseCtx, client, delivery, start)
+		if err != nil && leaseCtx.Err() != nil {
+			break
+		}
+
+		attempt := &models.WebhookAttempt{
+			DeliveryID:     delivery.ID,
+			Attempt:        delivery.Attempts,
+			ResponseStatus: status,
+			DurationMs:     time.Since(start).Milliseconds(),
+			AttemptedAt:    start,
+		}
+		delivery.ResponseStatus = status
+		switch {
+		case err == nil:
+			now := time.Now()
+			delivery.Status = models.WebhookDeliverySucceeded
+			delivery.DeliveredAt = &now
+			delivery.LastError = ""
+		case delivery.Attempts >= opts.MaxAttempts:
+			attempt.Error = err.Error()
+			delivery.Status = models.WebhookDeliveryDead
+			delivery.LastError = err.Error()
+			slog.Warn("Webhook delivery dead after maximum attempts",
+				"id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+				"attempts", delivery.Attempts, "error", err.Error())
+		default:
+			attempt.Error = err.Error()
+			delivery.NextAttemptAt = time.Now().Add(retryBackoff(delivery.Attempts, opts.MaxBackoff))
+			delivery.LastError = err.Error()
+		}
+
+		if err := store.RecordWebhookAttempt(ctx, delivery, attempt); err != nil {
+			slog.Warn("Failed to record webhook attempt", "id", delivery.ID, "error", err.Error())
+		}
+	}
+	return len(claimed)
+}
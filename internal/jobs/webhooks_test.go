@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/webhooks"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// fakeWebhookStore hands out deliveries once and records their attempts
+type fakeWebhookStore struct {
+	pending  []models.WebhookDelivery
+	recorded map[int64]models.WebhookDelivery
+	attempts []models.WebhookAttempt
+}
+
+func (s *fakeWebhookStore) ClaimWebhookDeliveries(_ context.Context, limit int, _ time.Duration) ([]models.WebhookDelivery, error) {
+	n := min(limit, len(s.pending))
+	claimed := s.pending[:n]
+	s.pending = s.pending[n:]
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+	return claimed, nil
+}
+
+func (s *fakeWebhookStore) RecordWebhookAttempt(_ context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	s.recorded[delivery.ID] = *delivery
+	s.attempts = append(s.attempts, *attempt)
+	return nil
+}
+
+// =============================================================================
+// Webhook Dispatcher Tests
+// =============================================================================
+
+func TestDispatchWebhooks_RecordsOutcomes(t *testing.T) {
+	const secret = "whsec_dispatcher-test"
+	var verified int
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if webhooks.Verify(secret, r.Header.Get(webhooks.SignatureHeader), body, time.Now(), time.Minute) == nil {
+			verified++
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer receiver.Close()
+
+	up := &models.WebhookSubscription{ID: 1, URL: receiver.URL + "/up", Secret: secret}
+	down := &models.WebhookSubscription{ID: 2, URL: receiver.URL + "/down", Secret: secret}
+	payload := json.RawMessage(`{"id":5,"type":"ItemCreated"}`)
+	store := &fakeWebhookStore{
+		recorded: map[int64]models.WebhookDelivery{},
+		pending: []models.WebhookDelivery{
+			{ID: 10, Status: models.WebhookDeliveryPending, Payload: payload, Subscription: up},
+			{ID: 11, Status: models.WebhookDeliveryPending, Payload: payload, Subscription: down, Attempts: 2},
+			{ID: 12, Status: models.WebhookDeliveryPending, Payload: payload, Subscription: down, Attempts: 4},
+		},
+	}
+	opts := WebhookOptions{PollInterval: time.Hour, MaxAttempts: 5, MaxBackoff: time.Hour}
+
+	before := time.Now()
+	if claimed := dispatchWebhooks(context.Background(), store, receiver.Client(), opts); claimed != 3 {
+		t.Fatalf("claimed = %d, want 3", claimed)
+	}
+
+	if verified != 3 {
+		t.Errorf("receiver verified %d signatures, want 3", verified)
+	}
+	if d := store.recorded[10]; d.Status != models.WebhookDeliverySucceeded || d.DeliveredAt == nil || d.ResponseStatus != http.StatusOK {
+		t.Errorf("delivery 10 = %+v, want succeeded", d)
+	}
+	// Third attempt failed: retried after 4s
+	d := store.recorded[11]
+	if d.Status != models.WebhookDeliveryPending || d.ResponseStatus != http.StatusServiceUnavailable || d.LastError == "" {
+		t.Errorf("delivery 11 = %+v, want pending with error", d)
+	}
+	if delay := d.NextAttemptAt.Sub(before); delay < 4*time.Second || delay > 5*time.Second {
+		t.Errorf("delivery 11 retry delay = %v, want about 4s", delay)
+	}
+	if d := store.recorded[12]; d.Status != models.WebhookDeliveryDead {
+		t.Errorf("delivery 12 status = %q, want dead after 5 attempts", d.Status)
+	}
+
+	if len(store.attempts) != 3 || store.attempts[1].Attempt != 3 || store.attempts[1].Error == "" || store.attempts[0].Error != "" {
+		t.Errorf("attempts = %+v", store.attempts)
+	}
+}
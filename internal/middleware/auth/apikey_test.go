@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// fakeAPIKeyStore is an in-memory APIKeyStore keyed by hash.
+type fakeAPIKeyStore struct {
+	keys    map[string]*models.APIKey
+	err     error
+	touched []int64
+}
+
+func (s *fakeAPIKeyStore) GetAPIKeyByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	key, ok := s.keys[hash]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return key, nil
+}
+
+func (s *fakeAPIKeyStore) TouchAPIKey(_ context.Context, id int64, _ time.Time) error {
+	s.touched = append(s.touched, id)
+	return nil
+}
+
+// newFakeAPIKeyStore generates a key, stores it and returns the store and plaintext key.
+func newFakeAPIKeyStore(t *testing.T, stored models.APIKey) (*fakeAPIKeyStore, string) {
+	t.Helper()
+	key, prefix, hash, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	stored.Prefix = prefix
+	stored.KeyHash = hash
+	return &fakeAPIKeyStore{keys: map[string]*models.APIKey{hash: &stored}}, key
+}
+
+// performAPIKeyRequest runs a request through APIKeyMiddleware and Middleware
+// and returns the recorder and the claims seen by the handler.
+func performAPIKeyRequest(t *testing.T, store APIKeyStore, apiKey, authHeader string) (*httptest.ResponseRecorder, *Claims) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := NewVerifier(context.Background(), newTestConfig())
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	var seen *Claims
+	router := gin.New()
+	router.GET("/protected", APIKeyMiddleware(store), Middleware(verifier), func(c *gin.Context) {
+		seen, _ = GetClaims(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if apiKey != "" {
+		req.Header.Set(APIKeyHeader, apiKey)
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+// =============================================================================
+// APIKeyMiddleware Tests
+// =============================================================================
+
+func TestAPIKeyMiddleware_AcceptsValidKey(t *testing.T) {
+	store, key := newFakeAPIKeyStore(t, models.APIKey{ID: 7, Scopes: "items:read items:write"})
+
+	w, claims := performAPIKeyRequest(t, store, key, "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if claims == nil {
+		t.Fatal("claims were not stored in context")
+	}
+	if claims.Subject != "api-key:7" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "api-key:7")
+	}
+	if !claims.HasScope("items:write") || len(claims.Roles) != 0 {
+		t.Errorf("Scopes = %v, Roles = %v, want key scopes and no roles", claims.Scopes, claims.Roles)
+	}
+	if len(store.touched) != 1 || store.touched[0] != 7 {
+		t.Errorf("touched = %v, want [7]", store.touched)
+	}
+}
+
+func TestAPIKeyMiddleware_RejectsInvalidKeys_TableDriven(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		stored models.APIKey
+		key    func(key string) string
+	}{
+		{
+			name:   "unknown key",
+			stored: models.APIKey{ID: 1},
+			key:    func(key string) string { return key + "x" },
+		},
+		{
+			name:   "revoked key",
+			stored: models.APIKey{ID: 1, RevokedAt: &past},
+			key:    func(key string) string { return key },
+		},
+		{
+			name:   "expired key",
+			stored: models.APIKey{ID: 1, ExpiresAt: &past},
+			key:    func(key string) string { return key },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, key := newFakeAPIKeyStore(t, tt.stored)
+
+			// A valid bearer token must not rescue a bad API key
+			token := signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), validClaims())
+			w, claims := performAPIKeyRequest(t, store, tt.key(key), "Bearer "+token)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+			if claims != nil {
+				t.Error("handler should not run for rejected key")
+			}
+			if len(store.touched) != 0 {
+				t.Error("rejected key should not be marked as used")
+			}
+		})
+	}
+}
+
+func TestAPIKeyMiddleware_FallsBackToBearerToken(t *testing.T) {
+	store := &fakeAPIKeyStore{}
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), validClaims())
+
+	w, claims := performAPIKeyRequest(t, store, "", "Bearer "+token)
+	if w.Code != http.StatusOK || claims == nil || claims.Subject != "user-1" {
+		t.Errorf("bearer: status = %d, claims = %+v, want 200 with subject user-1", w.Code, claims)
+	}
+
+	w, _ = performAPIKeyRequest(t, store, "", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("no credentials: status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAPIKeyMiddleware_SkipsRecentTouch(t *testing.T) {
+	recent := time.Now().Add(-time.Second)
+	store, key := newFakeAPIKeyStore(t, models.APIKey{ID: 3, LastUsedAt: &recent})
+
+	w, _ := performAPIKeyRequest(t, store, key, "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if len(store.touched) != 0 {
+		t.Errorf("touched = %v, want no update within %v", store.touched, apiKeyTouchInterval)
+	}
+}
+
+func TestAPIKeyMiddleware_StoreErrorReturns500(t *testing.T) {
+	store := &fakeAPIKeyStore{err: errors.New("connection refused")}
+
+	w, claims := performAPIKeyRequest(t, store, "tak_anything", "")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if claims != nil {
+		t.Error("handler should not run when the store fails")
+	}
+}
+
+func TestAPIKeyMiddleware_ScopesAreEnforced(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store, key := newFakeAPIKeyStore(t, models.APIKey{ID: 1, Scopes: "items:read"})
+
+	router := gin.New()
+	router.GET("/read", APIKeyMiddleware(store), RequireScopes("items:read"), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/write", APIKeyMiddleware(store), RequireScopes("items:write"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for path, want := range map[string]int{"/read": http.StatusOK, "/write": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
+// =============================================================================
+// NewAPIKey Tests
+// =============================================================================
+
+func TestNewAPIKey_FormatAndHash(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	if !strings.HasPrefix(key, apiKeyPrefix) || !strings.HasPrefix(key, prefix) || len(prefix) != apiKeyDisplayLength {
+		t.Errorf("key %q / prefix %q have unexpected format", key, prefix)
+	}
+	if hash != HashAPIKey(key) || strings.Contains(hash, key) {
+		t.Error("hash should be the SHA-256 of the key")
+	}
+
+	other, _, _, _ := NewAPIKey()
+	if other == key {
+		t.Error("NewAPIKey() should generate unique keys")
+	}
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// claimsContextKey is the gin context key under which validated claims are stored
+const claimsContextKey = "auth.claims"
+
+// Middleware returns a gin middleware that validates bearer tokens with the given Verifier.
+// On success the typed claims are stored in the context (see GetClaims).
+// On failure the request is aborted with 401 Unauthorized.
+// Requests already authenticated by an earlier middleware (e.g. APIKeyMiddleware) pass through.
+func Middleware(v *Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetClaims(c); ok {
+			c.Next()
+			return
+		}
+
+		tokenString, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abort(c, problem.CodeUnauthenticated, "Missing or malformed bearer token")
+			return
+		}
+
+		claims, err := v.Verify(c.Request.Context(), tokenString)
+		if err != nil {
+			slog.DebugContext(c.Request.Context(), "Token validation failed",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"error", err.Error(),
+			)
+			code, message := tokenError(err)
+			abort(c, code, message)
+			return
+		}
+
+		c.Set(claimsContextKey, claims)
+		c.Next()
+	}
+}
+
+// GetClaims returns the claims stored by Middleware or APIKeyMiddleware, if any
+func GetClaims(c *gin.Context) (*Claims, bool) {
+	value, exists := c.Get(claimsContextKey)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*Claims)
+	return claims, ok
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// tokenError maps JWT validation errors to client-facing codes and message keys
+func tokenError(err error) (problem.Code, string) {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return problem.CodeTokenExpired, "Token has expired"
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return problem.CodeInvalidToken, "Token is not valid yet"
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return problem.CodeInvalidToken, "Token issuer is not accepted"
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return problem.CodeInvalidToken, "Token audience is not accepted"
+	default:
+		return problem.CodeInvalidToken, "Invalid token"
+	}
+}
+
+// abort stops the request with a 401 problem of code and a WWW-Authenticate challenge.
+// message is an i18n key.
+func abort(c *gin.Context, code problem.Code, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	problem.Abort(c, problem.New(code, i18n.T(c.Request.Context(), message)))
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// Test constants
+const (
+	testJWTSecret = "this-is-a-very-long-secret-key-for-testing-at-least-32-chars"
+	testIssuer    = "template-api"
+	testAudience  = "template-api-clients"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// newTestConfig returns a JWT config with issuer and audience checks enabled.
+func newTestConfig() *config.JWTConfig {
+	return &config.JWTConfig{
+		Secret:        testJWTSecret,
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: time.Hour,
+		Issuer:        testIssuer,
+		Audience:      testAudience,
+		RolesClaim:    "roles",
+		ScopesClaim:   "scope",
+	}
+}
+
+// validClaims returns claims that pass all checks of newTestConfig.
+func validClaims() jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		Subject:   "user-1",
+		Issuer:    testIssuer,
+		Audience:  jwt.ClaimStrings{testAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+	}
+}
+
+// signToken signs claims with the given method and key.
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// performRequest runs a request with the given Authorization header through
+// the middleware and returns the recorder and the claims seen by the handler.
+func performRequest(t *testing.T, cfg *config.JWTConfig, authHeader string) (*httptest.ResponseRecorder, *Claims) {
+	t.Helper()
+
+	verifier, err := NewVerifier(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	return performVerifiedRequest(t, verifier, authHeader)
+}
+
+// performVerifiedRequest is performRequest with an existing Verifier.
+func performVerifiedRequest(t *testing.T, verifier *Verifier, authHeader string) (*httptest.ResponseRecorder, *Claims) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var seen *Claims
+	router := gin.New()
+	router.GET("/protected", Middleware(verifier), func(c *gin.Context) {
+		seen, _ = GetClaims(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, seen
+}
+
+// =============================================================================
+// Middleware Tests
+// =============================================================================
+
+func TestMiddleware_AcceptsValidToken(t *testing.T) {
+	cfg := newTestConfig()
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), validClaims())
+
+	w, claims := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if claims == nil {
+		t.Fatal("claims were not stored in context")
+	}
+	if claims.Subject != "user-1" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "user-1")
+	}
+}
+
+func TestMiddleware_RejectsInvalidTokens_TableDriven(t *testing.T) {
+	cfg := newTestConfig()
+	secret := []byte(testJWTSecret)
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	notYetValid := validClaims()
+	notYetValid.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "someone-else"
+
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.ClaimStrings{"other-service"}
+
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name       string
+		authHeader string
+	}{
+		{
+			name:       "missing header",
+			authHeader: "",
+		},
+		{
+			name:       "wrong scheme",
+			authHeader: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:       "empty bearer token",
+			authHeader: "Bearer ",
+		},
+		{
+			name:       "garbage token",
+			authHeader: "Bearer not-a-jwt",
+		},
+		{
+			name:       "wrong secret",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("another-secret-that-is-also-32-chars-long"), validClaims()),
+		},
+		{
+			name:       "disallowed algorithm",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS512, secret, validClaims()),
+		},
+		{
+			name:       "expired token",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, secret, expired),
+		},
+		{
+			name:       "token not valid yet",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, secret, notYetValid),
+		},
+		{
+			name:       "wrong issuer",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, secret, wrongIssuer),
+		},
+		{
+			name:       "wrong audience",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, secret, wrongAudience),
+		},
+		{
+			name:       "missing expiry",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, secret, noExpiry),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, claims := performRequest(t, cfg, tt.authHeader)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+			if claims != nil {
+				t.Error("handler should not run for rejected token")
+			}
+			if w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("WWW-Authenticate header should be set")
+			}
+		})
+	}
+}
+
+func TestMiddleware_SkipsIssuerAndAudienceWhenNotConfigured(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Issuer = ""
+	cfg.Audience = ""
+
+	claims := validClaims()
+	claims.Issuer = "anyone"
+	claims.Audience = nil
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims)
+
+	w, _ := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestMiddleware_ToleratesClockSkewWithinLeeway(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Leeway = time.Minute
+
+	claims := validClaims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims)
+
+	w, _ := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// =============================================================================
+// GetClaims Tests
+// =============================================================================
+
+func TestGetClaims_ReturnsFalseWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	claims, ok := GetClaims(c)
+
+	if ok || claims != nil {
+		t.Error("GetClaims() should return false when no claims are stored")
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// RoleAdmin is the role required for destructive operations
+const RoleAdmin = "admin"
+
+// ScopeItemsWrite is the scope API keys need to create and update items
+const ScopeItemsWrite = "items:write"
+
+// RequireRoles returns a middleware that allows callers having at least one of the given roles.
+// It must run after Middleware; requests without claims are rejected with 401,
+// callers lacking every role with 403.
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			abort(c, problem.CodeUnauthenticated, "Authentication required")
+			return
+		}
+
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+		abortForbidden(c, "Requires one of roles: {0}", strings.Join(roles, ", "))
+	}
+}
+
+// RequireScopes returns a middleware that allows callers granted all of the given scopes.
+// It must run after Middleware; requests without claims are rejected with 401,
+// callers missing any scope with 403.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok {
+			abort(c, problem.CodeUnauthenticated, "Authentication required")
+			return
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				c.Header("WWW-Authenticate", `Bearer realm="api", error="insufficient_scope", scope="`+strings.Join(scopes, " ")+`"`)
+				abortForbidden(c, "Requires scopes: {0}", strings.Join(scopes, " "))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireAPIKeyScopes returns a middleware that applies RequireScopes to callers
+// authenticated with an API key. Bearer token callers pass; their access is
+// governed by roles.
+func RequireAPIKeyScopes(scopes ...string) gin.HandlerFunc {
+	requireScopes := RequireScopes(scopes...)
+	return func(c *gin.Context) {
+		if !IsAPIKey(c) {
+			c.Next()
+			return
+		}
+		requireScopes(c)
+	}
+}
+
+// abortForbidden stops the request with a 403 forbidden problem; message is an i18n key
+func abortForbidden(c *gin.Context, message string, params ...string) {
+	problem.Abort(c, problem.New(problem.CodeForbidden, i18n.T(c.Request.Context(), message, params...)))
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/problem"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// signMapClaims signs validClaims plus extra claims with the test secret.
+func signMapClaims(t *testing.T, extra map[string]interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(validClaims())
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	claims := jwt.MapClaims{}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatalf("failed to unmarshal claims: %v", err)
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims)
+}
+
+// performAuthorizedRequest runs a request through Middleware followed by guard.
+func performAuthorizedRequest(t *testing.T, guard gin.HandlerFunc, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := NewVerifier(context.Background(), newTestConfig())
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	router := gin.New()
+	group := router.Group("/admin", Middleware(verifier), guard)
+	group.GET("", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// =============================================================================
+// RequireRoles Tests
+// =============================================================================
+
+func TestRequireRoles_TableDriven(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     map[string]interface{}
+		required   []string
+		wantStatus int
+	}{
+		{
+			name:       "has required role",
+			claims:     map[string]interface{}{"roles": []string{"admin"}},
+			required:   []string{"admin"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "has one of several roles",
+			claims:     map[string]interface{}{"roles": []string{"editor"}},
+			required:   []string{"admin", "editor"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "lacks role",
+			claims:     map[string]interface{}{"roles": []string{"viewer"}},
+			required:   []string{"admin"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no roles claim",
+			claims:     nil,
+			required:   []string{"admin"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performAuthorizedRequest(t, RequireRoles(tt.required...), signMapClaims(t, tt.claims))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequireRoles_ForbiddenUsesProblemDetails(t *testing.T) {
+	w := performAuthorizedRequest(t, RequireRoles("admin"), signMapClaims(t, nil))
+
+	var body problem.Details
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not a problem: %v", err)
+	}
+	if w.Header().Get("Content-Type") != problem.ContentType {
+		t.Errorf("Content-Type = %q, want %q", w.Header().Get("Content-Type"), problem.ContentType)
+	}
+	if body.Code != problem.CodeForbidden || body.Status != http.StatusForbidden || body.Detail == "" {
+		t.Errorf("body = %+v, want forbidden problem with detail", body)
+	}
+}
+
+func TestRequireRoles_RejectsWithoutAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin", RequireRoles("admin"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// =============================================================================
+// RequireScopes Tests
+// =============================================================================
+
+func TestRequireScopes_TableDriven(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     map[string]interface{}
+		required   []string
+		wantStatus int
+	}{
+		{
+			name:       "space-delimited scope string",
+			claims:     map[string]interface{}{"scope": "items:read items:write"},
+			required:   []string{"items:write"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "scope array",
+			claims:     map[string]interface{}{"scope": []string{"items:read", "items:write"}},
+			required:   []string{"items:read", "items:write"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing one of required scopes",
+			claims:     map[string]interface{}{"scope": "items:read"},
+			required:   []string{"items:read", "items:write"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no scope claim",
+			claims:     nil,
+			required:   []string{"items:read"},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performAuthorizedRequest(t, RequireScopes(tt.required...), signMapClaims(t, tt.claims))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Claim Mapping Tests
+// =============================================================================
+
+func TestVerify_MapsNestedClaimPaths(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RolesClaim = "realm_access.roles"
+	cfg.ScopesClaim = "scp"
+
+	verifier, err := NewVerifier(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	token := signMapClaims(t, map[string]interface{}{
+		"realm_access": map[string]interface{}{"roles": []string{"admin", "editor"}},
+		"scp":          []string{"items:write"},
+	})
+
+	claims, err := verifier.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if !claims.HasRole("admin") || !claims.HasRole("editor") {
+		t.Errorf("Roles = %v, want admin and editor", claims.Roles)
+	}
+	if !claims.HasScope("items:write") {
+		t.Errorf("Scopes = %v, want items:write", claims.Scopes)
+	}
+}
+
+func TestTokenIssuer_WritesRolesToConfiguredClaim(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.RolesClaim = "realm_access.roles"
+
+	token, _, err := NewTokenIssuer(cfg).IssueAccessToken("42", []string{"admin"})
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+
+	verifier, err := NewVerifier(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	claims, err := verifier.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if !claims.HasRole("admin") {
+		t.Errorf("Roles = %v, want admin", claims.Roles)
+	}
+}
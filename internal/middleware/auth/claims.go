@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// Claims represents the validated JWT claims of the authenticated caller.
+// Roles and Scopes are read from the claims named by JWT_ROLES_CLAIM and JWT_SCOPES_CLAIM.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles  []string `json:"-"`
+	Scopes []string `json:"-"`
+}
+
+// HasRole reports whether the caller has the given role
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// HasScope reports whether the caller was granted the given scope
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// newClaims converts validated raw claims into Claims using the configured claim mapping
+func newClaims(raw jwt.MapClaims, cfg *config.JWTConfig) (*Claims, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(data, &claims.RegisteredClaims); err != nil {
+		return nil, fmt.Errorf("failed to decode registered claims: %w", err)
+	}
+	claims.Roles = claimStrings(raw, cfg.RolesClaim)
+	claims.Scopes = claimStrings(raw, cfg.ScopesClaim)
+	return claims, nil
+}
+
+// claimStrings reads a list claim at a dot-separated path (e.g. "realm_access.roles").
+// Accepts JSON arrays of strings and space-delimited strings (OAuth 2.0 "scope").
+func claimStrings(raw map[string]interface{}, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var value interface{} = raw
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// setClaimStrings writes a list claim at a dot-separated path, creating nested objects
+func setClaimStrings(raw map[string]interface{}, path string, values []string) {
+	if path == "" || len(values) == 0 {
+		return
+	}
+
+	parts := strings.Split(path, ".")
+	obj := raw
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := obj[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			obj[part] = next
+		}
+		obj = next
+	}
+	obj[parts[len(parts)-1]] = values
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// JWK represents a public JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS represents a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWKS builds the public key set for all configured asymmetric keys, sorted by key ID.
+// The shared HS256 secret is never published.
+func NewJWKS(cfg *config.JWTConfig) JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(cfg.VerificationKeys))}
+	for kid, key := range cfg.VerificationKeys {
+		if jwk, ok := publicJWK(kid, key); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// publicJWK encodes a public key as a JWK
+func publicJWK(kid string, key crypto.PublicKey) (JWK, bool) {
+	method, err := SigningMethodForKey(key)
+	if err != nil {
+		return JWK{}, false
+	}
+	jwk := JWK{Use: "sig", Alg: method.Alg(), Kid: kid}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBigInt(k.N)
+		jwk.E = encodeBigInt(big.NewInt(int64(k.E)))
+	case *ecdsa.PublicKey:
+		// Uncompressed SEC 1 point encoding: 0x04 || X || Y
+		point, err := k.Bytes()
+		if err != nil {
+			return JWK{}, false
+		}
+		size := (len(point) - 1) / 2
+		jwk.Kty = "EC"
+		jwk.Crv = k.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(point[1 : 1+size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(point[1+size:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	}
+	return jwk, true
+}
+
+// PublicKey decodes the JWK into a public key.
+// Supported keys match the signing algorithms: RSA, EC P-256 and OKP Ed25519.
+func (k JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		if n.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA key must be at least 2048 bits")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid P-256 coordinates")
+		}
+		// Uncompressed SEC 1 point encoding: 0x04 || X || Y
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url-encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// encodeBigInt base64url-encodes an integer in big-endian form
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// errUnknownKey is returned when a token references a key that is not configured
+var errUnknownKey = errors.New("unknown signing key")
+
+// SigningMethodForKey returns the JWT algorithm used with an asymmetric public key
+func SigningMethodForKey(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		return jwt.SigningMethodES256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// validMethods lists the algorithms accepted for the configured keys
+func validMethods(cfg *config.JWTConfig) []string {
+	seen := make(map[string]bool)
+	if cfg.Secret != "" {
+		seen[jwt.SigningMethodHS256.Alg()] = true
+	}
+	for _, key := range cfg.VerificationKeys {
+		if method, err := SigningMethodForKey(key); err == nil {
+			seen[method.Alg()] = true
+		}
+	}
+	// Remote keys are not known up front, accept every supported asymmetric algorithm
+	if cfg.IssuerURL != "" {
+		for _, method := range []jwt.SigningMethod{jwt.SigningMethodRS256, jwt.SigningMethodES256, jwt.SigningMethodEdDSA} {
+			seen[method.Alg()] = true
+		}
+	}
+
+	methods := make([]string, 0, len(seen))
+	for alg := range seen {
+		methods = append(methods, alg)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// keyFunc resolves the verification key for a token.
+// HS256 tokens use the shared secret; asymmetric tokens are matched by "kid"
+// against local keys first, then the OIDC issuer's keys, and must use the
+// algorithm that belongs to the key type. fromIssuer is set when the key is
+// one of the OIDC issuer's.
+func (v *Verifier) keyFunc(ctx context.Context, fromIssuer *bool) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+			if v.cfg.Secret == "" {
+				return nil, errUnknownKey
+			}
+			return []byte(v.cfg.Secret), nil
+		}
+
+		key, err := lookupKey(v.cfg.VerificationKeys, token)
+		if err != nil && v.remote != nil {
+			kid, _ := token.Header["kid"].(string)
+			key, err = v.remote.key(ctx, kid)
+			*fromIssuer = err == nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		method, err := SigningMethodForKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if method.Alg() != token.Method.Alg() {
+			return nil, fmt.Errorf("algorithm %s does not match key type", token.Method.Alg())
+		}
+		return key, nil
+	}
+}
+
+// lookupKey finds the key named by the token's "kid" header.
+// Tokens without "kid" are accepted only when exactly one key is configured.
+func lookupKey(keys map[string]crypto.PublicKey, token *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if len(keys) != 1 {
+			return nil, errUnknownKey
+		}
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, errUnknownKey
+	}
+	return key, nil
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// generateKey generates a signing key of the named type ("rsa", "ec" or "ed25519").
+func generateKey(t *testing.T, kind string) crypto.Signer {
+	t.Helper()
+	var (
+		key crypto.Signer
+		err error
+	)
+	switch kind {
+	case "rsa":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ec":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ed25519":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		t.Fatalf("unknown key kind %q", kind)
+	}
+	if err != nil {
+		t.Fatalf("failed to generate %s key: %v", kind, err)
+	}
+	return key
+}
+
+// newAsymmetricConfig returns a config that signs with key under the given kid.
+func newAsymmetricConfig(key crypto.Signer, kid string) *config.JWTConfig {
+	cfg := newTestConfig()
+	cfg.Secret = ""
+	cfg.SigningKey = key
+	cfg.SigningKeyID = kid
+	cfg.VerificationKeys = map[string]crypto.PublicKey{kid: key.Public()}
+	return cfg
+}
+
+// signWithKid signs claims with the given method, key and "kid" header.
+func signWithKid(t *testing.T, method jwt.SigningMethod, key interface{}, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, validClaims())
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// =============================================================================
+// Asymmetric Signing Tests
+// =============================================================================
+
+func TestTokenIssuer_AsymmetricRoundTrip_TableDriven(t *testing.T) {
+	tests := []struct {
+		kind    string
+		wantAlg string
+	}{
+		{kind: "rsa", wantAlg: "RS256"},
+		{kind: "ec", wantAlg: "ES256"},
+		{kind: "ed25519", wantAlg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			cfg := newAsymmetricConfig(generateKey(t, tt.kind), "key-1")
+
+			token, _, err := NewTokenIssuer(cfg).IssueAccessToken("42", nil)
+			if err != nil {
+				t.Fatalf("IssueAccessToken() error = %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("failed to parse token header: %v", err)
+			}
+			if parsed.Method.Alg() != tt.wantAlg {
+				t.Errorf("alg = %q, want %q", parsed.Method.Alg(), tt.wantAlg)
+			}
+			if parsed.Header["kid"] != "key-1" {
+				t.Errorf("kid = %v, want %q", parsed.Header["kid"], "key-1")
+			}
+
+			w, claims := performRequest(t, cfg, "Bearer "+token)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+			}
+			if claims.Subject != "42" {
+				t.Errorf("Subject = %q, want %q", claims.Subject, "42")
+			}
+		})
+	}
+}
+
+func TestMiddleware_AcceptsTokensFromRotatedKey(t *testing.T) {
+	previous := generateKey(t, "ec")
+	cfg := newAsymmetricConfig(generateKey(t, "ec"), "current")
+	cfg.VerificationKeys["previous"] = previous.Public()
+
+	token := signWithKid(t, jwt.SigningMethodES256, previous, "previous")
+
+	w, _ := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestMiddleware_RejectsAsymmetricKeyMisuse_TableDriven(t *testing.T) {
+	ecKey := generateKey(t, "ec")
+	rsaKey := generateKey(t, "rsa")
+	cfg := newAsymmetricConfig(ecKey, "ec-key")
+	cfg.VerificationKeys["rsa-key"] = rsaKey.Public()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "unknown kid",
+			token: signWithKid(t, jwt.SigningMethodES256, ecKey, "other"),
+		},
+		{
+			name:  "missing kid with several keys",
+			token: signWithKid(t, jwt.SigningMethodES256, ecKey, ""),
+		},
+		{
+			name:  "signed by unconfigured key",
+			token: signWithKid(t, jwt.SigningMethodES256, generateKey(t, "ec"), "ec-key"),
+		},
+		{
+			name:  "algorithm does not match key type",
+			token: signWithKid(t, jwt.SigningMethodRS256, rsaKey, "ec-key"),
+		},
+		{
+			name:  "HS256 without configured secret",
+			token: signWithKid(t, jwt.SigningMethodHS256, []byte(testJWTSecret), ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := performRequest(t, cfg, "Bearer "+tt.token)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+func TestMiddleware_AcceptsSecretAndKeysTogether(t *testing.T) {
+	key := generateKey(t, "ed25519")
+	cfg := newAsymmetricConfig(key, "ed-key")
+	cfg.Secret = testJWTSecret
+
+	tokens := []string{
+		signWithKid(t, jwt.SigningMethodHS256, []byte(testJWTSecret), ""),
+		signWithKid(t, jwt.SigningMethodEdDSA, key, "ed-key"),
+	}
+
+	for _, token := range tokens {
+		w, _ := performRequest(t, cfg, "Bearer "+token)
+		if w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	}
+}
+
+// =============================================================================
+// NewJWKS Tests
+// =============================================================================
+
+func TestNewJWKS_PublishesAllVerificationKeys(t *testing.T) {
+	cfg := newAsymmetricConfig(generateKey(t, "rsa"), "a-rsa")
+	cfg.VerificationKeys["b-ec"] = generateKey(t, "ec").Public()
+	cfg.VerificationKeys["c-ed"] = generateKey(t, "ed25519").Public()
+	cfg.Secret = testJWTSecret
+
+	set := NewJWKS(cfg)
+
+	if len(set.Keys) != 3 {
+		t.Fatalf("JWKS has %d keys, want 3", len(set.Keys))
+	}
+
+	want := []JWK{
+		{Kty: "RSA", Alg: "RS256", Kid: "a-rsa"},
+		{Kty: "EC", Alg: "ES256", Kid: "b-ec", Crv: "P-256"},
+		{Kty: "OKP", Alg: "EdDSA", Kid: "c-ed", Crv: "Ed25519"},
+	}
+	for i, w := range want {
+		got := set.Keys[i]
+		if got.Kty != w.Kty || got.Alg != w.Alg || got.Kid != w.Kid || got.Crv != w.Crv {
+			t.Errorf("Keys[%d] = %+v, want kty/alg/kid/crv %+v", i, got, w)
+		}
+		if got.Use != "sig" {
+			t.Errorf("Keys[%d].Use = %q, want %q", i, got.Use, "sig")
+		}
+	}
+
+	if set.Keys[0].N == "" || set.Keys[0].E != "AQAB" {
+		t.Errorf("RSA key missing modulus or exponent: %+v", set.Keys[0])
+	}
+	if len(set.Keys[1].X) != 43 || len(set.Keys[1].Y) != 43 {
+		t.Errorf("EC coordinates should be 32 bytes base64url: %+v", set.Keys[1])
+	}
+	if len(set.Keys[2].X) != 43 {
+		t.Errorf("Ed25519 key should be 32 bytes base64url: %+v", set.Keys[2])
+	}
+}
+
+func TestNewJWKS_EmptyForSecretOnlyConfig(t *testing.T) {
+	set := NewJWKS(newTestConfig())
+
+	if set.Keys == nil || len(set.Keys) != 0 {
+		t.Errorf("JWKS keys = %v, want empty non-nil slice", set.Keys)
+	}
+}
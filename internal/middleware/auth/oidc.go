@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minKeyRefreshInterval rate-limits JWKS refetches triggered by unknown key IDs
+	minKeyRefreshInterval = 30 * time.Second
+
+	// maxDiscoveryResponseBytes caps discovery and JWKS response bodies
+	maxDiscoveryResponseBytes = 1 << 20
+)
+
+// discoveryDocument holds the fields used from /.well-known/openid-configuration
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// remoteKeySet caches the JWKS of an OIDC issuer.
+// Keys are refreshed periodically and on demand when a token names an unknown key ID.
+type remoteKeySet struct {
+	issuer             string // "iss" of the issuer's tokens, from discovery
+	client             *http.Client
+	jwksURI            string
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+
+	refreshMu   sync.Mutex // serializes fetches and guards lastAttempt
+	lastAttempt time.Time  // of the last fetch, successful or not
+}
+
+// discoverKeySet resolves the issuer's jwks_uri via OIDC discovery and loads its keys
+func discoverKeySet(ctx context.Context, client *http.Client, issuerURL string, refreshInterval time.Duration) (*remoteKeySet, error) {
+	var doc discoveryDocument
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	if err := fetchJSON(ctx, client, discoveryURL, &doc); err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(issuerURL, "/") {
+		return nil, fmt.Errorf("discovery issuer %q does not match %q", doc.Issuer, issuerURL)
+	}
+	if doc.JWKSURI == "" {
+		return nil, errors.New("discovery document has no jwks_uri")
+	}
+
+	s := &remoteKeySet{
+		issuer:             doc.Issuer,
+		client:             client,
+		jwksURI:            doc.JWKSURI,
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: minKeyRefreshInterval,
+		keys:               make(map[string]crypto.PublicKey),
+	}
+	if err := s.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// run refreshes the keys every refreshInterval until ctx is done.
+// Failed refreshes keep serving the previously fetched keys.
+func (s *remoteKeySet) run(ctx context.Context) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.refresh(ctx); err != nil {
+				slog.Warn("Failed to refresh OIDC issuer keys", "jwks_uri", s.jwksURI, "error", err.Error())
+			}
+		}
+	}
+}
+
+// key returns the issuer key with the given ID. Unknown IDs trigger a
+// rate-limited refetch so keys rotated in by the issuer are picked up.
+// An empty ID matches only when the issuer publishes exactly one key.
+func (s *remoteKeySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	if err := s.refreshStale(ctx); err != nil {
+		slog.Warn("Failed to refresh OIDC issuer keys", "jwks_uri", s.jwksURI, "error", err.Error())
+	}
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, errUnknownKey
+}
+
+// refreshStale refetches the keys unless a fetch was attempted within
+// minRefreshInterval. Staleness is checked while holding refreshMu, so
+// concurrent requests with unknown key IDs share a single fetch, and failed
+// fetches count as attempts so an unavailable issuer is not hammered.
+func (s *remoteKeySet) refreshStale(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if time.Since(s.lastAttempt) < s.minRefreshInterval {
+		return nil
+	}
+	return s.fetch(ctx)
+}
+
+// lookup finds a cached key
+func (s *remoteKeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the JWKS and replaces the cached keys
+func (s *remoteKeySet) refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.fetch(ctx)
+}
+
+// fetch fetches the JWKS and replaces the cached keys; the caller holds refreshMu.
+// Keys with unsupported types or a non-signature use are skipped.
+func (s *remoteKeySet) fetch(ctx context.Context) error {
+	s.lastAttempt = time.Now()
+
+	var set JWKS
+	if err := fetchJSON(ctx, s.client, s.jwksURI, &set); err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			slog.Debug("Skipping unsupported issuer key", "kid", jwk.Kid, "error", err.Error())
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no usable signing keys")
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+	return nil
+}
+
+// fetchJSON performs a GET request and decodes a JSON response
+func fetchJSON(ctx context.Context, client *http.Client, url string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only body
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDiscoveryResponseBytes)).Decode(dst); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// oidcTestIssuer is a local OIDC issuer serving discovery and JWKS documents.
+type oidcTestIssuer struct {
+	server          *httptest.Server
+	discoveryIssuer string // overrides the advertised issuer when set
+
+	mu           sync.Mutex
+	keys         map[string]crypto.Signer
+	jwksRequests int
+	failing      bool // JWKS requests fail with 503
+}
+
+// newOIDCTestIssuer starts an issuer publishing the given keys.
+func newOIDCTestIssuer(t *testing.T, keys map[string]crypto.Signer) *oidcTestIssuer {
+	t.Helper()
+	issuer := &oidcTestIssuer{keys: keys}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		advertised := issuer.server.URL
+		if issuer.discoveryIssuer != "" {
+			advertised = issuer.discoveryIssuer
+		}
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:  advertised,
+			JWKSURI: issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.jwksRequests++
+		if issuer.failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		set := JWKS{}
+		for kid, key := range issuer.keys {
+			if jwk, ok := publicJWK(kid, key.Public()); ok {
+				set.Keys = append(set.Keys, jwk)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// addKey publishes an additional key.
+func (i *oidcTestIssuer) addKey(kid string, key crypto.Signer) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys[kid] = key
+}
+
+// setFailing makes JWKS requests fail or succeed.
+func (i *oidcTestIssuer) setFailing(failing bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.failing = failing
+}
+
+// requests returns the number of JWKS fetches so far.
+func (i *oidcTestIssuer) requests() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.jwksRequests
+}
+
+// sign issues a token from this issuer signed with the named key.
+func (i *oidcTestIssuer) sign(t *testing.T, kid string, key crypto.Signer) string {
+	t.Helper()
+	claims := validClaims()
+	claims.Issuer = i.server.URL
+	return signWithKID(t, kid, key, claims)
+}
+
+// signWithKID signs claims with key and names it in the "kid" header.
+func signWithKID(t *testing.T, kid string, key crypto.Signer, claims jwt.Claims) string {
+	t.Helper()
+	method, err := SigningMethodForKey(key.Public())
+	if err != nil {
+		t.Fatalf("SigningMethodForKey() error = %v", err)
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// newOIDCConfig returns a config that trusts only the given issuer.
+func newOIDCConfig(issuerURL string) *config.JWTConfig {
+	return &config.JWTConfig{
+		Audience:            testAudience,
+		RolesClaim:          "roles",
+		ScopesClaim:         "scope",
+		IssuerURL:           issuerURL,
+		JWKSRefreshInterval: time.Hour,
+	}
+}
+
+// =============================================================================
+// OIDC Verifier Tests
+// =============================================================================
+
+func TestNewVerifier_OIDC_AcceptsIssuerTokens_TableDriven(t *testing.T) {
+	for _, kind := range []string{"rsa", "ec", "ed25519"} {
+		t.Run(kind, func(t *testing.T) {
+			key := generateKey(t, kind)
+			issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": key})
+
+			verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+
+			w, claims := performVerifiedRequest(t, verifier, "Bearer "+issuer.sign(t, "k1", key))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+			}
+			if claims.Issuer != issuer.server.URL {
+				t.Errorf("Issuer = %q, want %q", claims.Issuer, issuer.server.URL)
+			}
+		})
+	}
+}
+
+func TestNewVerifier_OIDC_RejectsTokenFromOtherIssuer(t *testing.T) {
+	key := generateKey(t, "ec")
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": key})
+
+	verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	// Signed by a published key, but claiming another issuer
+	claims := validClaims()
+	claims.Issuer = "https://someone-else.example.com"
+	w, _ := performVerifiedRequest(t, verifier, "Bearer "+signWithKID(t, "k1", key, claims))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestNewVerifier_OIDC_SeparatesLocalAndIssuerTokens_TableDriven(t *testing.T) {
+	key := generateKey(t, "ec")
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": key})
+
+	cfg := newOIDCConfig(issuer.server.URL)
+	cfg.Secret = testJWTSecret
+	cfg.Issuer = testIssuer
+	verifier, err := NewVerifier(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	withIssuer := func(iss string) jwt.RegisteredClaims {
+		claims := validClaims()
+		claims.Issuer = iss
+		return claims
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "local token with local issuer",
+			token:    signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), withIssuer(testIssuer)),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "local token claiming OIDC issuer",
+			token:    signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), withIssuer(issuer.server.URL)),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "OIDC token with OIDC issuer",
+			token:    signWithKID(t, "k1", key, withIssuer(issuer.server.URL)),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "OIDC token claiming local issuer",
+			token:    signWithKID(t, "k1", key, withIssuer(testIssuer)),
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := performVerifiedRequest(t, verifier, "Bearer "+tt.token)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewVerifier_OIDC_PicksUpRotatedKey(t *testing.T) {
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"old": generateKey(t, "ec")})
+
+	verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	verifier.remote.minRefreshInterval = 0
+
+	rotated := generateKey(t, "ec")
+	issuer.addKey("new", rotated)
+
+	w, _ := performVerifiedRequest(t, verifier, "Bearer "+issuer.sign(t, "new", rotated))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := issuer.requests(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2", got)
+	}
+}
+
+func TestNewVerifier_OIDC_RateLimitsUnknownKeyRefetch(t *testing.T) {
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": generateKey(t, "ec")})
+
+	verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	unknown := generateKey(t, "ec")
+	for range 3 {
+		w, _ := performVerifiedRequest(t, verifier, "Bearer "+issuer.sign(t, "unknown", unknown))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	}
+
+	if got := issuer.requests(); got != 1 {
+		t.Errorf("JWKS requests = %d, want 1 (refetch should be rate limited)", got)
+	}
+}
+
+func TestNewVerifier_OIDC_ConcurrentUnknownKeysShareOneRefetch(t *testing.T) {
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": generateKey(t, "ec")})
+
+	verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	verifier.remote.lastAttempt = time.Now().Add(-time.Hour)
+
+	unknown := generateKey(t, "ec")
+	token := issuer.sign(t, "unknown", unknown)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := verifier.Verify(context.Background(), token); err == nil {
+				t.Error("Verify() should reject a token with an unknown key")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := issuer.requests(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2 (startup and one shared refetch)", got)
+	}
+}
+
+func TestNewVerifier_OIDC_RateLimitsFailedRefetch(t *testing.T) {
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": generateKey(t, "ec")})
+
+	verifier, err := NewVerifier(context.Background(), newOIDCConfig(issuer.server.URL))
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+	verifier.remote.lastAttempt = time.Now().Add(-time.Hour)
+	issuer.setFailing(true)
+
+	unknown := generateKey(t, "ec")
+	for range 3 {
+		w, _ := performVerifiedRequest(t, verifier, "Bearer "+issuer.sign(t, "unknown", unknown))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+		}
+	}
+
+	if got := issuer.requests(); got != 2 {
+		t.Errorf("JWKS requests = %d, want 2 (failed refetches should be rate limited)", got)
+	}
+}
+
+func TestNewVerifier_OIDC_RefreshesKeysInBackground(t *testing.T) {
+	issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": generateKey(t, "ec")})
+	cfg := newOIDCConfig(issuer.server.URL)
+	cfg.JWKSRefreshInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := NewVerifier(ctx, cfg); err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for issuer.requests() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("JWKS requests = %d, want background refreshes", issuer.requests())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNewVerifier_OIDC_FailsOnDiscoveryProblems_TableDriven(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) string
+	}{
+		{
+			name: "advertised issuer mismatch",
+			setup: func(t *testing.T) string {
+				issuer := newOIDCTestIssuer(t, map[string]crypto.Signer{"k1": generateKey(t, "ec")})
+				issuer.discoveryIssuer = "https://impostor.example.com"
+				return issuer.server.URL
+			},
+		},
+		{
+			name: "no usable keys",
+			setup: func(t *testing.T) string {
+				return newOIDCTestIssuer(t, map[string]crypto.Signer{}).server.URL
+			},
+		},
+		{
+			name: "discovery not found",
+			setup: func(t *testing.T) string {
+				server := httptest.NewServer(http.NotFoundHandler())
+				t.Cleanup(server.Close)
+				return server.URL
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewVerifier(context.Background(), newOIDCConfig(tt.setup(t))); err == nil {
+				t.Error("NewVerifier() should return an error")
+			}
+		})
+	}
+}
+
+// =============================================================================
+// JWK.PublicKey Tests
+// =============================================================================
+
+func TestJWK_PublicKey_RoundTrip_TableDriven(t *testing.T) {
+	for _, kind := range []string{"rsa", "ec", "ed25519"} {
+		t.Run(kind, func(t *testing.T) {
+			public := generateKey(t, kind).Public()
+			jwk, ok := publicJWK("kid", public)
+			if !ok {
+				t.Fatal("publicJWK() failed")
+			}
+
+			decoded, err := jwk.PublicKey()
+			if err != nil {
+				t.Fatalf("PublicKey() error = %v", err)
+			}
+
+			equal, ok := public.(interface{ Equal(crypto.PublicKey) bool })
+			if !ok || !equal.Equal(decoded) {
+				t.Error("decoded key does not match original")
+			}
+		})
+	}
+}
+
+func TestJWK_PublicKey_RejectsUnsupportedKeys_TableDriven(t *testing.T) {
+	tests := []struct {
+		name string
+		jwk  JWK
+	}{
+		{name: "unknown kty", jwk: JWK{Kty: "oct"}},
+		{name: "EC P-384", jwk: JWK{Kty: "EC", Crv: "P-384"}},
+		{name: "OKP X25519", jwk: JWK{Kty: "OKP", Crv: "X25519"}},
+		{name: "short RSA modulus", jwk: JWK{Kty: "RSA", N: "AQAB", E: "AQAB"}},
+		{name: "bad base64", jwk: JWK{Kty: "OKP", Crv: "Ed25519", X: "!!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.jwk.PublicKey(); err == nil {
+				t.Error("PublicKey() should return an error")
+			}
+		})
+	}
+}
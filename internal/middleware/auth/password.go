@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordCost is the bcrypt cost of user password hashes. The dummy hash that
+// logins of unknown users are compared against has the same cost, so both take
+// as long.
+const PasswordCost = bcrypt.DefaultCost
+
+// HashPassword returns the bcrypt hash of a user password, as stored in users.password_hash
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
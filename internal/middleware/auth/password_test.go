@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// =============================================================================
+// HashPassword Tests
+// =============================================================================
+
+func TestHashPassword_VerifiesWithPasswordCost(t *testing.T) {
+	hash, err := HashPassword("correct horse battery")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("correct horse battery")); err != nil {
+		t.Errorf("CompareHashAndPassword() error = %v, want the password to match", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("wrong")); err == nil {
+		t.Error("CompareHashAndPassword() should reject another password")
+	}
+	if cost, err := bcrypt.Cost([]byte(hash)); err != nil || cost != PasswordCost {
+		t.Errorf("Cost() = %d, %v, want %d", cost, err, PasswordCost)
+	}
+}
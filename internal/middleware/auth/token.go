@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// refreshTokenBytes is the entropy of an opaque refresh token
+const refreshTokenBytes = 32
+
+// TokenIssuer signs access tokens that Middleware accepts
+type TokenIssuer struct {
+	cfg *config.JWTConfig
+	now func() time.Time
+}
+
+// NewTokenIssuer creates a new TokenIssuer from JWT configuration.
+// Tokens are signed with the asymmetric signing key when configured, otherwise with the HS256 secret.
+func NewTokenIssuer(cfg *config.JWTConfig) *TokenIssuer {
+	return &TokenIssuer{
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+// IssueAccessToken signs a short-lived access token for the given subject.
+// Roles are written to the configured roles claim.
+// Returns the signed token and its expiry time.
+func (i *TokenIssuer) IssueAccessToken(subject string, roles []string) (string, time.Time, error) {
+	jti, err := RandomID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := i.now()
+	expiresAt := now.Add(i.cfg.AccessExpiry)
+
+	claims := jwt.MapClaims{
+		"jti": jti,
+		"sub": subject,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": expiresAt.Unix(),
+	}
+	if i.cfg.Issuer != "" {
+		claims["iss"] = i.cfg.Issuer
+	}
+	if i.cfg.Audience != "" {
+		claims["aud"] = i.cfg.Audience
+	}
+	setClaimStrings(claims, i.cfg.RolesClaim, roles)
+
+	signed, err := i.sign(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// sign signs claims with the configured key, setting "kid" for asymmetric keys
+func (i *TokenIssuer) sign(claims jwt.MapClaims) (string, error) {
+	if i.cfg.SigningKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(i.cfg.Secret))
+	}
+
+	method, err := SigningMethodForKey(i.cfg.SigningKey.Public())
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = i.cfg.SigningKeyID
+	return token.SignedString(i.cfg.SigningKey)
+}
+
+// RefreshExpiry returns the lifetime of a newly issued refresh token
+func (i *TokenIssuer) RefreshExpiry() time.Duration {
+	return i.cfg.RefreshExpiry
+}
+
+// NewRefreshToken generates an opaque refresh token.
+// Returns the token for the client and its hash for storage.
+func NewRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the storage hash of a refresh token.
+// Refresh tokens are high-entropy, so a plain SHA-256 is sufficient.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RandomID returns a random 128-bit hex identifier (token IDs, family IDs)
+func RandomID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
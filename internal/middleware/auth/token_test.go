@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// TokenIssuer Tests
+// =============================================================================
+
+func TestTokenIssuer_IssueAccessToken_AcceptedByMiddleware(t *testing.T) {
+	cfg := newTestConfig()
+	issuer := NewTokenIssuer(cfg)
+
+	token, expiresAt, err := issuer.IssueAccessToken("42", nil)
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+
+	wantExpiry := time.Now().Add(cfg.AccessExpiry)
+	if diff := expiresAt.Sub(wantExpiry); diff > time.Second || diff < -time.Second {
+		t.Errorf("expiresAt = %v, want ~%v", expiresAt, wantExpiry)
+	}
+
+	w, claims := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if claims.Subject != "42" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "42")
+	}
+	if claims.ID == "" {
+		t.Error("token should carry a jti")
+	}
+}
+
+func TestTokenIssuer_IssueAccessToken_RejectedAfterExpiry(t *testing.T) {
+	cfg := newTestConfig()
+	issuer := NewTokenIssuer(cfg)
+	issuer.now = func() time.Time { return time.Now().Add(-time.Hour) }
+
+	token, _, err := issuer.IssueAccessToken("42", nil)
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+
+	w, _ := performRequest(t, cfg, "Bearer "+token)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// =============================================================================
+// NewRefreshToken Tests
+// =============================================================================
+
+func TestNewRefreshToken_HashMatchesHashRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+
+	if token == "" || hash == "" {
+		t.Fatal("token and hash should not be empty")
+	}
+	if token == hash {
+		t.Error("hash should differ from token")
+	}
+	if got := HashRefreshToken(token); got != hash {
+		t.Errorf("HashRefreshToken() = %q, want %q", got, hash)
+	}
+}
+
+func TestNewRefreshToken_GeneratesUniqueTokens(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		token, _, err := NewRefreshToken()
+		if err != nil {
+			t.Fatalf("NewRefreshToken() error = %v", err)
+		}
+		if seen[token] {
+			t.Fatalf("duplicate token generated: %q", token)
+		}
+		seen[token] = true
+	}
+}
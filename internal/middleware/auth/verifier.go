@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// discoveryTimeout bounds OIDC discovery and JWKS requests
+const discoveryTimeout = 10 * time.Second
+
+// Verifier validates access tokens against the locally configured keys and,
+// when JWT_ISSUER_URL is set, the keys published by an external OIDC issuer
+type Verifier struct {
+	cfg    *config.JWTConfig
+	parser *jwt.Parser
+	remote *remoteKeySet // nil unless an OIDC issuer is configured
+}
+
+// NewVerifier creates a Verifier from JWT configuration.
+// With an OIDC issuer configured it performs discovery and fetches the issuer's
+// JWKS, then refreshes the keys in the background until ctx is done.
+func NewVerifier(ctx context.Context, cfg *config.JWTConfig) (*Verifier, error) {
+	v := &Verifier{
+		cfg:    cfg,
+		parser: newParser(cfg),
+	}
+
+	if cfg.IssuerURL != "" {
+		client := &http.Client{Timeout: discoveryTimeout}
+		remote, err := discoverKeySet(ctx, client, cfg.IssuerURL, cfg.JWKSRefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize OIDC issuer %s: %w", cfg.IssuerURL, err)
+		}
+		go remote.run(ctx)
+		v.remote = remote
+	}
+
+	return v, nil
+}
+
+// Verify parses a token and validates its signature and claims
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	raw := jwt.MapClaims{}
+	var fromIssuer bool
+	if _, err := v.parser.ParseWithClaims(tokenString, raw, v.keyFunc(ctx, &fromIssuer)); err != nil {
+		return nil, err
+	}
+	if err := v.checkIssuer(raw, fromIssuer); err != nil {
+		return nil, err
+	}
+	return newClaims(raw, v.cfg)
+}
+
+// checkIssuer validates the "iss" claim: tokens verified with the OIDC issuer's
+// keys must carry its issuer, locally verified tokens the configured Issuer (if any).
+// Local and external tokens therefore cannot pass for each other.
+func (v *Verifier) checkIssuer(raw jwt.MapClaims, fromIssuer bool) error {
+	expected := v.cfg.Issuer
+	if fromIssuer {
+		expected = v.remote.issuer
+	}
+	if expected == "" {
+		return nil
+	}
+	if iss, _ := raw.GetIssuer(); iss != expected {
+		return jwt.ErrTokenInvalidIssuer
+	}
+	return nil
+}
+
+// newParser creates a JWT parser enforcing the configured algorithms, expiry,
+// not-before and audience rules; the issuer is checked by checkIssuer
+func newParser(cfg *config.JWTConfig) *jwt.Parser {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods(cfg)),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	return jwt.NewParser(opts...)
+}
@@ -0,0 +1,76 @@
+// Package metrics records Prometheus metrics of HTTP requests.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners probing
+// random paths cannot create new series
+const unmatchedRoute = "unmatched"
+
+// Options configures the HTTP metrics
+type Options struct {
+	DurationBuckets []float64 // Seconds
+	SizeBuckets     []float64 // Bytes
+	ExcludePaths    []string  // Routes not recorded, including routes below them
+}
+
+// Middleware registers the HTTP metrics with reg and returns a middleware recording them.
+// Requests are labelled by method, route template (c.FullPath()) and status class.
+// Register it before the routes so it runs for all of them.
+func Middleware(reg prometheus.Registerer, opts Options) gin.HandlerFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status class.",
+	}, []string{"method", "route", "status"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time to serve HTTP requests by method, route and status class.",
+		Buckets: opts.DurationBuckets,
+	}, []string{"method", "route", "status"})
+	size := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_response_size_bytes",
+		Help:    "Size of HTTP response bodies by method, route and status class.",
+		Buckets: opts.SizeBuckets,
+	}, []string{"method", "route", "status"})
+	inFlight := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served by method and route.",
+	}, []string{"method", "route"})
+	reg.MustRegister(requests, duration, size, inFlight)
+
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if utils.PathExcluded(route, opts.ExcludePaths) {
+			c.Next()
+			return
+		}
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+
+		start := time.Now()
+		inFlight.WithLabelValues(method, route).Inc()
+		defer inFlight.WithLabelValues(method, route).Dec()
+
+		c.Next()
+
+		status := statusClass(c.Writer.Status())
+		requests.WithLabelValues(method, route, status).Inc()
+		duration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+		size.WithLabelValues(method, route, status).Observe(float64(max(c.Writer.Size(), 0)))
+	}
+}
+
+// statusClass returns the class of an HTTP status, e.g. "2xx"
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
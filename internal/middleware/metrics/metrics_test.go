@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// setupRouter returns a router recording metrics into a fresh registry.
+// GET /items/:id answers 200 unless the ID is "missing"; GET /health answers 200.
+func setupRouter(t *testing.T) (*gin.Engine, *prometheus.Registry) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	reg := prometheus.NewRegistry()
+	router := gin.New()
+	router.Use(Middleware(reg, Options{
+		DurationBuckets: []float64{0.1, 1},
+		SizeBuckets:     []float64{10, 100},
+		ExcludePaths:    []string{"/metrics", "/health"},
+	}))
+	router.GET("/items/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+		c.String(http.StatusOK, "item")
+	})
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/health/ready", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router, reg
+}
+
+// inFlight returns the value of the single in-flight series of reg
+func inFlight(t *testing.T, reg *prometheus.Registry) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() == "http_requests_in_flight" {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatal("http_requests_in_flight not gathered")
+	return 0
+}
+
+func get(router *gin.Engine, path string) {
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// =============================================================================
+// Middleware Tests
+// =============================================================================
+
+func TestMiddleware_LabelsByRouteTemplateAndStatusClass(t *testing.T) {
+	router, reg := setupRouter(t)
+
+	get(router, "/items/1")
+	get(router, "/items/2")
+	get(router, "/items/missing")
+	get(router, "/no/such/path")
+
+	want := `
+# HELP http_requests_total HTTP requests by method, route and status class.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",route="/items/:id",status="2xx"} 2
+http_requests_total{method="GET",route="/items/:id",status="4xx"} 1
+http_requests_total{method="GET",route="unmatched",status="4xx"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "http_requests_total"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(reg, "http_request_duration_seconds", "http_response_size_bytes"); got != 6 {
+		t.Errorf("histogram series = %d, want 3 per histogram", got)
+	}
+}
+
+func TestMiddleware_RecordsResponseSize(t *testing.T) {
+	router, reg := setupRouter(t)
+
+	get(router, "/items/1")
+
+	want := `
+# HELP http_response_size_bytes Size of HTTP response bodies by method, route and status class.
+# TYPE http_response_size_bytes histogram
+http_response_size_bytes_bucket{method="GET",route="/items/:id",status="2xx",le="10"} 1
+http_response_size_bytes_bucket{method="GET",route="/items/:id",status="2xx",le="100"} 1
+http_response_size_bytes_bucket{method="GET",route="/items/:id",status="2xx",le="+Inf"} 1
+http_response_size_bytes_sum{method="GET",route="/items/:id",status="2xx"} 4
+http_response_size_bytes_count{method="GET",route="/items/:id",status="2xx"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "http_response_size_bytes"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMiddleware_SkipsExcludedPaths(t *testing.T) {
+	router, reg := setupRouter(t)
+
+	get(router, "/health")
+	get(router, "/health/ready")
+
+	if got := testutil.CollectAndCount(reg, "http_requests_total", "http_requests_in_flight"); got != 0 {
+		t.Errorf("series for excluded paths = %d, want 0", got)
+	}
+}
+
+func TestMiddleware_TracksRequestsInFlight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := prometheus.NewRegistry()
+	router := gin.New()
+	router.Use(Middleware(reg, Options{DurationBuckets: []float64{1}, SizeBuckets: []float64{1}}))
+
+	var during float64
+	router.GET("/slow", func(c *gin.Context) {
+		during = inFlight(t, reg)
+		c.Status(http.StatusOK)
+	})
+	get(router, "/slow")
+
+	if during != 1 {
+		t.Errorf("in flight during request = %v, want 1", during)
+	}
+	if after := inFlight(t, reg); after != 0 {
+		t.Errorf("in flight after request = %v, want 0", after)
+	}
+}
@@ -0,0 +1,62 @@
+// Package requestid gives every HTTP request an ID that ties together its
+// response, log records and audit events.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header carries the request ID in requests and responses
+const Header = "X-Request-ID"
+
+// maxLength fits the audit_events.request_id column
+const maxLength = 100
+
+type contextKey struct{}
+
+// Middleware returns a middleware assigning each request an ID: the caller's
+// X-Request-ID when it is valid, otherwise a generated one. The ID is stored in
+// the request context (see FromContext) and returned in the X-Request-ID header.
+// Register it first so every later middleware and handler sees the ID.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if !valid(id) {
+			id = rand.Text()
+		}
+
+		c.Request = c.Request.WithContext(WithID(c.Request.Context(), id))
+		c.Header(Header, id)
+		c.Next()
+	}
+}
+
+// WithID returns a context carrying the request ID id
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored by WithID, or "" outside a request
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// valid reports whether a caller's ID is safe to echo and log: 1 to 100
+// letters, digits and "-", "_", ".", ":"
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		alphanumeric := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+		if !alphanumeric && !strings.ContainsRune("-_.:", r) {
+			return false
+		}
+	}
+	return true
+}
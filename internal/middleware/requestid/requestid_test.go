@@ -0,0 +1,96 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// serve sends a request with the given X-Request-ID header (none when empty)
+// and returns the response header and the ID seen by the handler
+func serve(t *testing.T, header string) (responseID, contextID string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/items", func(c *gin.Context) {
+		contextID = FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	if header != "" {
+		req.Header.Set(Header, header)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Header().Get(Header), contextID
+}
+
+// =============================================================================
+// Middleware Tests
+// =============================================================================
+
+func TestMiddleware_AcceptsValidIDs(t *testing.T) {
+	tests := []string{"req-1", "01J9Z3K4:checkout_v2.retry", strings.Repeat("a", 100)}
+
+	for _, id := range tests {
+		t.Run(id[:min(len(id), 20)], func(t *testing.T) {
+			responseID, contextID := serve(t, id)
+
+			if responseID != id || contextID != id {
+				t.Errorf("response = %q, context = %q, want %q", responseID, contextID, id)
+			}
+		})
+	}
+}
+
+func TestMiddleware_GeneratesMissingOrInvalidIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing", header: ""},
+		{name: "too long", header: strings.Repeat("a", 101)},
+		{name: "spaces", header: "req 1"},
+		{name: "log injection", header: "req-1\",\"level\":\"ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseID, contextID := serve(t, tt.header)
+
+			if responseID == "" || responseID == tt.header || contextID != responseID {
+				t.Errorf("response = %q, context = %q, want a generated ID in both", responseID, contextID)
+			}
+		})
+	}
+}
+
+func TestMiddleware_GeneratesUniqueIDs(t *testing.T) {
+	first, _ := serve(t, "")
+	second, _ := serve(t, "")
+
+	if first == second {
+		t.Errorf("generated IDs should differ, got %q twice", first)
+	}
+}
+
+// =============================================================================
+// FromContext Tests
+// =============================================================================
+
+func TestFromContext_EmptyOutsideRequest(t *testing.T) {
+	if got := FromContext(context.Background()); got != "" {
+		t.Errorf("FromContext() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,78 @@
+// Package tracing starts an OpenTelemetry span for each HTTP request.
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/GunarsK-templates/template-api/internal/utils"
+)
+
+// tracerName identifies the spans of this package
+const tracerName = "github.com/GunarsK-templates/template-api/internal/middleware/tracing"
+
+// Middleware returns a middleware starting a server span for each request, named
+// after its route template, e.g. "GET /api/v1/items/:id". A W3C traceparent header
+// continues the caller's trace. Matched routes get a child span around the rest
+// of the chain, named after the route template (e.g. "/api/v1/items/:id"), which
+// is stored in the request context, so repository queries and log records of the
+// request are attached to it. Register it before the routes so it runs for all of them.
+func Middleware(provider trace.TracerProvider, excludePaths []string) gin.HandlerFunc {
+	tracer := provider.Tracer(tracerName)
+	propagator := propagation.TraceContext{}
+
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if utils.PathExcluded(route, excludePaths) {
+			c.Next()
+			return
+		}
+
+		name := c.Request.Method
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(c.Request.Method),
+			semconv.URLPath(c.Request.URL.Path),
+			semconv.ClientAddress(c.ClientIP()),
+			semconv.UserAgentOriginal(c.Request.UserAgent()),
+		}
+		if route != "" {
+			name += " " + route
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		// Unmatched requests only get the server span
+		var handlerSpan trace.Span
+		if route != "" {
+			ctx, handlerSpan = tracer.Start(ctx, route, trace.WithSpanKind(trace.SpanKindInternal))
+		}
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		// Client errors are the client's problem, not failures of these spans
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+			if handlerSpan != nil {
+				handlerSpan.SetStatus(codes.Error, http.StatusText(status))
+			}
+		}
+		if handlerSpan != nil {
+			handlerSpan.End()
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// setupRouter returns a traced router and the exporter receiving its spans.
+// GET /items/:id answers 200, or 500 for the ID "broken"; the span of the
+// request context is recorded in handlerSpan.
+func setupRouter(t *testing.T, handlerSpan *trace.SpanContext) (*gin.Engine, *tracetest.InMemoryExporter) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	router := gin.New()
+	router.Use(Middleware(provider, []string{"/health"}))
+	router.GET("/items/:id", func(c *gin.Context) {
+		if handlerSpan != nil {
+			*handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		}
+		if c.Param("id") == "broken" {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	router.GET("/health/ready", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router, exporter
+}
+
+// spanOfKind returns the only span of kind
+func spanOfKind(t *testing.T, spans tracetest.SpanStubs, kind trace.SpanKind) tracetest.SpanStub {
+	t.Helper()
+	var found []tracetest.SpanStub
+	for _, span := range spans {
+		if span.SpanKind == kind {
+			found = append(found, span)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("%v spans = %d, want 1", kind, len(found))
+	}
+	return found[0]
+}
+
+// attributes returns the attributes of a span by key
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// =============================================================================
+// Middleware Tests
+// =============================================================================
+
+func TestMiddleware_StartsServerSpanPerRoute(t *testing.T) {
+	var handlerSpan trace.SpanContext
+	router, exporter := setupRouter(t, &handlerSpan)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/7", nil))
+
+	span := spanOfKind(t, exporter.GetSpans(), trace.SpanKindServer)
+	if span.Name != "GET /items/:id" || span.SpanKind != trace.SpanKindServer {
+		t.Errorf("span = %q (%v), want server span GET /items/:id", span.Name, span.SpanKind)
+	}
+	attrs := attributes(span)
+	if attrs["http.route"].AsString() != "/items/:id" || attrs["url.path"].AsString() != "/items/7" ||
+		attrs["http.response.status_code"].AsInt64() != http.StatusOK {
+		t.Errorf("attributes = %v, want route, path and status", span.Attributes)
+	}
+	if handlerSpan.TraceID() != span.SpanContext.TraceID() {
+		t.Error("handler context should carry a span of the request trace")
+	}
+}
+
+func TestMiddleware_StartsHandlerSpanAsChildOfServerSpan(t *testing.T) {
+	var handlerSpan trace.SpanContext
+	router, exporter := setupRouter(t, &handlerSpan)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/broken", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want server and handler span", len(spans))
+	}
+	server := spanOfKind(t, spans, trace.SpanKindServer)
+	child := spanOfKind(t, spans, trace.SpanKindInternal)
+	if child.Name != "/items/:id" {
+		t.Errorf("handler span = %q, want /items/:id", child.Name)
+	}
+	if child.Parent.SpanID() != server.SpanContext.SpanID() || child.SpanContext.TraceID() != server.SpanContext.TraceID() {
+		t.Errorf("handler span parent = %s, want the server span %s", child.Parent.SpanID(), server.SpanContext.SpanID())
+	}
+	if handlerSpan.SpanID() != child.SpanContext.SpanID() {
+		t.Error("handler context should carry the handler span")
+	}
+	if child.Status.Code != codes.Error {
+		t.Errorf("handler span status = %v, want error for a 500", child.Status.Code)
+	}
+}
+
+func TestMiddleware_UnmatchedRouteGetsOnlyServerSpan(t *testing.T) {
+	router, exporter := setupRouter(t, nil)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].SpanKind != trace.SpanKindServer {
+		t.Errorf("spans = %d, want only the server span", len(spans))
+	}
+}
+
+func TestMiddleware_ContinuesTraceparent(t *testing.T) {
+	router, exporter := setupRouter(t, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := spanOfKind(t, exporter.GetSpans(), trace.SpanKindServer)
+	if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" ||
+		span.Parent.SpanID().String() != "00f067aa0ba902b7" || !span.Parent.IsRemote() {
+		t.Errorf("span trace = %s, parent = %s, want the caller's trace and span",
+			span.SpanContext.TraceID(), span.Parent.SpanID())
+	}
+}
+
+func TestMiddleware_MarksServerErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		want codes.Code
+	}{
+		{path: "/items/7", want: codes.Unset},
+		{path: "/items/broken", want: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			router, exporter := setupRouter(t, nil)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if got := spanOfKind(t, exporter.GetSpans(), trace.SpanKindServer).Status.Code; got != tt.want {
+				t.Errorf("span status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_SkipsExcludedPaths(t *testing.T) {
+	router, exporter := setupRouter(t, nil)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	if got := len(exporter.GetSpans()); got != 0 {
+		t.Errorf("spans = %d, want none for excluded paths", got)
+	}
+}
@@ -0,0 +1,149 @@
+// Package migrate applies versioned SQL migrations to the database.
+//
+// Migrations are pairs of files named <version>_<name>.up.sql and
+// <version>_<name>.down.sql, embedded into the binary from the migrations
+// directory. Applied versions are recorded in the schema_migrations table.
+package migrate
+
+import (
+	"cmp"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dir is the source directory of the embedded migrations, relative to the module root
+const Dir = "internal/migrate/migrations"
+
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// fileNamePattern matches migration file names, e.g. 0001_create_items.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// namePattern matches the name part accepted by Create
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string // Empty when the migration cannot be rolled back
+}
+
+// Embedded returns the migrations compiled into the binary
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	return Load(sub)
+}
+
+// Load reads migrations from the root of fsys, sorted by version.
+// Every version needs an up file; down files are optional.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (want <version>_<name>.up|down.sql)", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d used by %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return migrations, nil
+}
+
+// Create writes an empty up/down migration pair in dir, numbered after the highest existing version.
+// Returns the paths of the created files.
+func Create(dir, name string) (upPath, downPath string, err error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", "_"))
+	if !namePattern.MatchString(name) {
+		return "", "", errors.New("migration name may only contain letters, digits, '-' and '_'")
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", "", fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+	existing, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	var version int64 = 1
+	if len(existing) > 0 {
+		version = existing[len(existing)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+	upPath, downPath = base+".up.sql", base+".down.sql"
+
+	for _, file := range []struct{ path, comment string }{
+		{upPath, "-- Write the schema change here\n"},
+		{downPath, "-- Write the statements that revert the up migration here\n"},
+	} {
+		// O_EXCL: never overwrite an existing migration
+		f, err := os.OpenFile(file.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+		_, err = f.WriteString(file.comment)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to write migration file: %w", err)
+		}
+	}
+	return upPath, downPath, nil
+}
@@ -0,0 +1,180 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// file returns an in-memory migration file with the given content.
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+// =============================================================================
+// Load Tests
+// =============================================================================
+
+func TestLoad_SortsAndPairsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":       file("CREATE INDEX i ON t (c);"),
+		"0002_create_t.up.sql":        file("CREATE TABLE t (c INT);"),
+		"0002_create_t.down.sql":      file("DROP TABLE t;"),
+		"0001_init.up.sql":            file("SELECT 1;"),
+		"README.md":                   file("not a migration"),
+		"0003_nested/ignored.up.sql":  file("SELECT 1;"),
+		"0001_init.down.sql":          file("SELECT 1;"),
+		"0010_add_index.down.sql":     file("DROP INDEX i;"),
+		"0004_irreversible.up.sql":    file("SELECT 1;"),
+		"0005_comment_only.up.sql":    file("-- intentionally empty\n"),
+		"0005_comment_only.down.sql":  file(""),
+		"0006_whitespace_name.up.sql": file("SELECT 1;"),
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	wantVersions := []int64{1, 2, 4, 5, 6, 10}
+	if len(migrations) != len(wantVersions) {
+		t.Fatalf("Load() returned %d migrations, want %d", len(migrations), len(wantVersions))
+	}
+	for i, want := range wantVersions {
+		if migrations[i].Version != want {
+			t.Errorf("migrations[%d].Version = %d, want %d", i, migrations[i].Version, want)
+		}
+	}
+
+	created := migrations[1]
+	if created.Name != "create_t" || created.Up != "CREATE TABLE t (c INT);" || created.Down != "DROP TABLE t;" {
+		t.Errorf("migration 2 = %+v, want paired up/down files", created)
+	}
+	if migrations[2].Down != "" {
+		t.Error("migration without down file should have empty Down")
+	}
+}
+
+func TestLoad_RejectsInvalidSets_TableDriven(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr string
+	}{
+		{
+			name:    "bad file name",
+			fsys:    fstest.MapFS{"create_items.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "uppercase name",
+			fsys:    fstest.MapFS{"0001_CreateItems.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "zero version",
+			fsys:    fstest.MapFS{"0000_init.up.sql": file("SELECT 1;")},
+			wantErr: "invalid migration version",
+		},
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"0001_one.up.sql": file("SELECT 1;"),
+				"0001_two.up.sql": file("SELECT 2;"),
+			},
+			wantErr: "migration version 1 used by",
+		},
+		{
+			name:    "down without up",
+			fsys:    fstest.MapFS{"0001_init.down.sql": file("SELECT 1;")},
+			wantErr: "has no up file",
+		},
+		{
+			name:    "empty up",
+			fsys:    fstest.MapFS{"0001_init.up.sql": file("  \n")},
+			wantErr: "has no up file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmbedded_LoadsShippedMigrations(t *testing.T) {
+	migrations, err := Embedded()
+	if err != nil {
+		t.Fatalf("Embedded() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Embedded() returned no migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %s has version %d, want contiguous version %d", m.Name, m.Version, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
+
+// =============================================================================
+// Create Tests
+// =============================================================================
+
+func TestCreate_NumbersAfterHighestVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0007_existing.up.sql"), []byte("SELECT 1;"), 0o600); err != nil {
+		t.Fatalf("failed to write migration: %v", err)
+	}
+
+	upPath, downPath, err := Create(dir, "Add-Widgets")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if filepath.Base(upPath) != "0008_add_widgets.up.sql" {
+		t.Errorf("up file = %q, want %q", filepath.Base(upPath), "0008_add_widgets.up.sql")
+	}
+	if filepath.Base(downPath) != "0008_add_widgets.down.sql" {
+		t.Errorf("down file = %q, want %q", filepath.Base(downPath), "0008_add_widgets.down.sql")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("created files should load: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Errorf("Load() returned %d migrations, want 2", len(migrations))
+	}
+}
+
+func TestCreate_StartsAtOneInEmptyDir(t *testing.T) {
+	upPath, _, err := Create(t.TempDir(), "init")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if filepath.Base(upPath) != "0001_init.up.sql" {
+		t.Errorf("up file = %q, want %q", filepath.Base(upPath), "0001_init.up.sql")
+	}
+}
+
+func TestCreate_RejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "drop table", "../escape", "emoji_🙂"} {
+		if _, _, err := Create(t.TempDir(), name); err == nil {
+			t.Errorf("Create(%q) should fail", name)
+		}
+	}
+}
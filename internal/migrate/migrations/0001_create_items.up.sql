@@ -0,0 +1,8 @@
+-- IF NOT EXISTS so databases with a hand-created items table can adopt migrations
+CREATE TABLE IF NOT EXISTS items (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
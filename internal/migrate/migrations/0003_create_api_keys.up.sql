@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+    id           BIGSERIAL PRIMARY KEY,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(16) NOT NULL,
+    key_hash     VARCHAR(64) NOT NULL UNIQUE,
+    scopes       VARCHAR(500) NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    expires_at   TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_keys_revoked_at ON api_keys (revoked_at);
@@ -0,0 +1,10 @@
+-- The text search configuration must match searchConfig in internal/repository/search.go.
+-- To change it, add a migration that drops and re-adds this column. Columns are
+-- coalesced so a NULL in one of them does not make the whole vector NULL.
+ALTER TABLE items
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_items_search_vector ON items USING GIN (search_vector);
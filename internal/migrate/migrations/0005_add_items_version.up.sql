@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Trashed rows would reappear as live items, so drop them first
+DELETE FROM items WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE items DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,4 @@
+-- Soft delete: rows with deleted_at set are in the trash until purged
+ALTER TABLE items ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_items_deleted_at ON items (deleted_at);
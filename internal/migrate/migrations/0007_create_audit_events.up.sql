@@ -0,0 +1,15 @@
+-- No foreign key to items: history outlives purged items
+CREATE TABLE audit_events (
+    id          BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id   BIGINT NOT NULL,
+    action      VARCHAR(20) NOT NULL,
+    actor       VARCHAR(255) NOT NULL,
+    request_id  VARCHAR(100) NOT NULL DEFAULT '',
+    diff        JSONB NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events (entity_type, entity_id);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
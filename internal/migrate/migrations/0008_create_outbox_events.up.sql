@@ -0,0 +1,15 @@
+-- Domain events written in the same transaction as the change they describe.
+-- The outbox relay publishes and then deletes them.
+CREATE TABLE outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    event_type      VARCHAR(50) NOT NULL,
+    aggregate_type  VARCHAR(50) NOT NULL,
+    aggregate_id    BIGINT NOT NULL,
+    payload         JSONB NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_outbox_events_next_attempt_at ON outbox_events (next_attempt_at, id);
@@ -0,0 +1,228 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// lockID is the Postgres advisory lock key held while migrating,
+// so concurrently starting instances apply migrations one at a time
+const lockID int64 = 7_245_001_731
+
+// createTableSQL creates the table recording applied migrations
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    BIGINT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// ErrNotInitialized is returned by Status when the schema_migrations table
+// does not exist yet, i.e. migrations have never been run
+var ErrNotInitialized = errors.New("migrations not initialized: schema_migrations does not exist")
+
+// Status describes a known migration and whether it has been applied
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // nil when pending
+}
+
+// Migrator applies migrations to a Postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a Migrator for the given migrations (see Embedded)
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Up applies all pending migrations in version order.
+// Each migration runs in its own transaction. Returns the number applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			err := inTx(ctx, conn, migration.Up,
+				`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			slog.Info("Applied migration", "version", migration.Version, "name", migration.Name)
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the given number of most recently applied migrations.
+// Returns the number rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+			err := inTx(ctx, conn, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+			if err != nil {
+				return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			slog.Info("Rolled back migration", "version", migration.Version, "name", migration.Name)
+			rolledBack++
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status reports every known migration and when it was applied.
+// It only reads, without taking the migration lock, so it neither waits for a
+// running migration nor creates schema_migrations; a missing table is reported
+// as ErrNotInitialized.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	done, err := m.readApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := done[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns the number of migrations that have not been applied.
+// Like Status it takes no lock, so it is cheap enough for health checks,
+// and a missing schema_migrations table is reported as ErrNotInitialized.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	done, err := m.readApplied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if _, ok := done[migration.Version]; !ok {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// readApplied returns the applied versions without the lock, or ErrNotInitialized
+// when schema_migrations does not exist
+func (m *Migrator) readApplied(ctx context.Context) (map[int64]time.Time, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotInitialized
+	}
+	return appliedVersions(ctx, m.db)
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock.
+// The schema_migrations table is created first if needed.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	// Advisory locks are per session, so lock and migrate on the same connection
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck // connection is returned to the pool
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Unlock even if ctx was canceled
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			slog.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return fn(conn)
+}
+
+// querier is a *sql.DB or *sql.Conn
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// appliedVersions returns applied migration versions and their apply times
+func appliedVersions(ctx context.Context, conn querier) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only query
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// inTx runs a migration script and its bookkeeping statement in one transaction
+func inTx(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
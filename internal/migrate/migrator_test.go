@@ -0,0 +1,167 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// recordingDB is a database/sql driver answering the queries of Status and
+// recording every statement it receives
+type recordingDB struct {
+	tableExists bool
+	applied     map[int64]time.Time
+
+	mu         sync.Mutex
+	statements []string
+}
+
+func (d *recordingDB) Connect(context.Context) (driver.Conn, error) { return &recordingConn{d}, nil }
+func (d *recordingDB) Driver() driver.Driver                        { return nil }
+
+// executed returns the recorded statements
+func (d *recordingDB) executed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.statements...)
+}
+
+type recordingConn struct{ db *recordingDB }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements not supported")
+}
+func (c *recordingConn) Close() error { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	c.db.statements = append(c.db.statements, query)
+	c.db.mu.Unlock()
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	c.db.statements = append(c.db.statements, query)
+	c.db.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "to_regclass"):
+		return &recordingRows{columns: []string{"exists"}, values: [][]driver.Value{{c.db.tableExists}}}, nil
+	case strings.Contains(query, "FROM schema_migrations"):
+		if !c.db.tableExists {
+			return nil, errors.New(`relation "schema_migrations" does not exist`)
+		}
+		rows := &recordingRows{columns: []string{"version", "applied_at"}}
+		for version, appliedAt := range c.db.applied {
+			rows.values = append(rows.values, []driver.Value{version, appliedAt})
+		}
+		return rows, nil
+	default:
+		return nil, errors.New("unexpected query: " + query)
+	}
+}
+
+type recordingRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *recordingRows) Columns() []string { return r.columns }
+func (r *recordingRows) Close() error      { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// testMigrations are two known migrations
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_items", Up: "CREATE TABLE items ()"},
+	{Version: 2, Name: "add_index", Up: "CREATE INDEX ON items ()"},
+}
+
+// =============================================================================
+// Status Tests
+// =============================================================================
+
+func TestMigrator_Status_ReadsWithoutLockOrDDL(t *testing.T) {
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := &recordingDB{tableExists: true, applied: map[int64]time.Time{1: appliedAt}}
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+
+	statuses, err := New(db, testMigrations).Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if len(statuses) != 2 || statuses[0].AppliedAt == nil || !statuses[0].AppliedAt.Equal(appliedAt) ||
+		statuses[1].AppliedAt != nil {
+		t.Errorf("statuses = %+v, want 1 applied and 2 pending", statuses)
+	}
+	for _, statement := range rec.executed() {
+		if strings.Contains(statement, "pg_advisory") || strings.Contains(statement, "CREATE") {
+			t.Errorf("Status() executed %q, want reads only", statement)
+		}
+	}
+}
+
+func TestMigrator_Status_NotInitialized(t *testing.T) {
+	rec := &recordingDB{}
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+
+	statuses, err := New(db, testMigrations).Status(context.Background())
+
+	if !errors.Is(err, ErrNotInitialized) || statuses != nil {
+		t.Errorf("Status() = %+v, %v, want ErrNotInitialized", statuses, err)
+	}
+	if got := rec.executed(); len(got) != 1 {
+		t.Errorf("statements = %q, want only the table lookup", got)
+	}
+}
+
+// =============================================================================
+// Pending Tests
+// =============================================================================
+
+func TestMigrator_Pending(t *testing.T) {
+	rec := &recordingDB{tableExists: true, applied: map[int64]time.Time{1: time.Now()}}
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+
+	pending, err := New(db, testMigrations).Pending(context.Background())
+
+	if err != nil || pending != 1 {
+		t.Errorf("Pending() = %d, %v, want 1", pending, err)
+	}
+}
+
+func TestMigrator_Pending_NotInitialized(t *testing.T) {
+	db := sql.OpenDB(&recordingDB{})
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err := New(db, testMigrations).Pending(context.Background())
+
+	if !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Pending() error = %v, want ErrNotInitialized", err)
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey represents a credential for machine clients.
+// Only the SHA-256 hash of the key is stored; the key itself is shown once on creation.
+type APIKey struct {
+	ID         int64      `json:"id" gorm:"primaryKey"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	Prefix     string     `json:"prefix" gorm:"size:16;not null"`
+	KeyHash    string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Scopes     string     `json:"scopes" gorm:"size:500;not null;default:''"` // Space-separated, e.g. "items:read items:write"
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns the key's scopes as a slice
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// IsActive reports whether the key is neither revoked nor expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"max=20,dive,required,max=50,excludesall= "`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is returned once on creation and contains the plaintext key
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEvent records one change to an entity: who made it, in which request and what changed
+type AuditEvent struct {
+	ID         int64           `json:"id" gorm:"primaryKey"`
+	EntityType string          `json:"entity_type" gorm:"size:50;not null"`
+	EntityID   int64           `json:"entity_id" gorm:"not null"`
+	Action     string          `json:"action" gorm:"size:20;not null"` // create, update, delete, restore or purge
+	Actor      string          `json:"actor" gorm:"size:255;not null"` // Token subject, api-key:<id>, anonymous or system:purger
+	RequestID  string          `json:"request_id,omitempty" gorm:"size:100;not null;default:''"`
+	Diff       json.RawMessage `json:"diff" gorm:"type:jsonb;not null" swaggertype:"object"` // {"field": {"before": ..., "after": ...}}
+	CreatedAt  time.Time       `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// ListAuditQuery represents the query parameters for listing audit events
+type ListAuditQuery struct {
+	EntityType string     `form:"entity_type" binding:"max=50"`
+	EntityID   int64      `form:"entity_id" binding:"omitempty,min=1"`
+	Action     string     `form:"action" binding:"omitempty,oneof=create update delete restore purge"`
+	Actor      string     `form:"actor" binding:"max=255"`
+	RequestID  string     `form:"request_id" binding:"max=100"`
+	Since      *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ItemHistoryQuery represents the query parameters for an item's history
+type ItemHistoryQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AuditPage represents one page of audit events, newest first
+type AuditPage struct {
+	Events []AuditEvent `json:"events"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// User represents an account that can obtain tokens
+type User struct {
+	ID           int64     `json:"id" gorm:"primaryKey"`
+	Username     string    `json:"username" gorm:"size:100;not null;uniqueIndex"`
+	PasswordHash string    `json:"-" gorm:"size:100;not null"`
+	Roles        string    `json:"roles" gorm:"size:255;not null;default:''"` // Comma-separated, e.g. "admin,editor"
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (User) TableName() string {
+	return "users"
+}
+
+// RoleList returns the user's roles as a slice
+func (u *User) RoleList() []string {
+	var roles []string
+	for _, role := range strings.Split(u.Roles, ",") {
+		if trimmed := strings.TrimSpace(role); trimmed != "" {
+			roles = append(roles, trimmed)
+		}
+	}
+	return roles
+}
+
+// RefreshToken represents a stored refresh token.
+// Tokens issued from the same login share a FamilyID; rotating a token
+// revokes it and issues a successor in the same family.
+type RefreshToken struct {
+	ID        int64      `gorm:"primaryKey"`
+	FamilyID  string     `gorm:"size:32;not null;index"`
+	UserID    int64      `gorm:"not null;index"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RevokedAt *time.Time `gorm:"index"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsActive reports whether the token is neither revoked nor expired
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// LoginRequest represents the request body for obtaining tokens
+type LoginRequest struct {
+	Username string `json:"username" binding:"required,max=100"`
+	Password string `json:"password" binding:"required,max=72"`
+}
+
+// RefreshRequest represents the request body for refreshing or revoking tokens
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse represents an issued access/refresh token pair
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
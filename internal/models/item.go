@@ -1,14 +1,22 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Item represents a sample resource
 type Item struct {
 	ID          int64     `json:"id" gorm:"primaryKey"`
 	Name        string    `json:"name" gorm:"size:200;not null"`
 	Description string    `json:"description,omitempty" gorm:"type:text"`
+	Version     int64     `json:"version" gorm:"not null;default:1"` // Incremented on every update, sent as ETag
 	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// DeletedAt is set while the item is in the trash; GORM queries skip such rows
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitzero" gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
 // TableName specifies the table name for GORM
@@ -27,3 +35,108 @@ type UpdateItemRequest struct {
 	Name        string `json:"name" binding:"required,max=200"`
 	Description string `json:"description,omitempty"`
 }
+
+// BulkItemOperation is one element of a bulk request.
+// Creates carry name and description, updates additionally the item ID, deletes only the ID.
+type BulkItemOperation struct {
+	Op          string `json:"op" binding:"required,oneof=create update delete" enums:"create,update,delete"`
+	ID          int64  `json:"id,omitempty" binding:"required_unless=Op create,excluded_if=Op create"`
+	Name        string `json:"name,omitempty" binding:"required_unless=Op delete,excluded_if=Op delete,max=200"`
+	Description string `json:"description,omitempty" binding:"excluded_if=Op delete"`
+	Version     *int64 `json:"version,omitempty" binding:"excluded_if=Op create"` // Only apply at this version, like If-Match
+}
+
+// BulkItemsRequest represents the request body for bulk item operations
+type BulkItemsRequest struct {
+	Operations []BulkItemOperation `json:"operations" binding:"required,min=1,max=1000"`
+}
+
+// BulkItemsQuery represents the query parameters for bulk item operations
+type BulkItemsQuery struct {
+	BestEffort bool `form:"best_effort"` // Apply operations independently instead of in one transaction
+}
+
+// BulkItemResult is the outcome of one bulk operation, at the same index as the operation
+type BulkItemResult struct {
+	Status int    `json:"status"`
+	Item   *Item  `json:"item,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkItemsResponse represents the response of a bulk request
+type BulkItemsResponse struct {
+	Results []BulkItemResult `json:"results"`
+}
+
+// ExportItemsQuery represents the query parameters for exporting items
+type ExportItemsQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+}
+
+// ImportItemsQuery represents the query parameters for importing items
+type ImportItemsQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	DryRun bool   `form:"dry_run"` // Only validate, do not store anything
+}
+
+// ImportLineError reports why a line of an import was rejected
+type ImportLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportItemsResponse summarizes an import.
+// Imports are all or nothing: Imported stays 0 if any row is invalid or on a dry run.
+type ImportItemsResponse struct {
+	Valid    int               `json:"valid"`
+	Invalid  int               `json:"invalid"`
+	Imported int               `json:"imported"`
+	DryRun   bool              `json:"dry_run"`
+	Errors   []ImportLineError `json:"errors,omitempty"` // First errors only
+}
+
+// ListItemsQuery represents the query parameters for listing items
+type ListItemsQuery struct {
+	Limit         int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset        int        `form:"offset" binding:"omitempty,min=0"`
+	Cursor        string     `form:"cursor" binding:"max=200"`
+	Sort          string     `form:"sort" binding:"max=200"`
+	NameContains  string     `form:"name_contains" binding:"max=200"`
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	IncludeTotal  bool       `form:"include_total"`
+}
+
+// ItemPage represents one page of a list of items
+type ItemPage struct {
+	Items      []Item `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"` // Empty on the last page or for sorts other than created_at
+	Total      *int64 `json:"total,omitempty"`       // Only when include_total=true
+}
+
+// ListTrashQuery represents the query parameters for listing deleted items
+type ListTrashQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SearchItemsQuery represents the query parameters for searching items
+type SearchItemsQuery struct {
+	Q      string `form:"q" binding:"required,max=200"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ItemSearchResult is an item matching a search, with its rank and highlighted text.
+// Highlights are HTML-escaped and wrap matches in <mark></mark>, so they can be rendered as HTML.
+type ItemSearchResult struct {
+	Item
+	Rank                 float64 `json:"rank"`
+	NameHighlight        string  `json:"name_highlight"`
+	DescriptionHighlight string  `json:"description_highlight,omitempty"`
+}
+
+// ItemSearchPage represents one page of search results, best match first
+type ItemSearchPage struct {
+	Items []ItemSearchResult `json:"items"`
+}
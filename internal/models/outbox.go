@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a domain event waiting to be published by the outbox relay
+type OutboxEvent struct {
+	ID            int64           `json:"id" gorm:"primaryKey"`
+	EventType     string          `json:"event_type" gorm:"size:50;not null"`     // ItemCreated, ItemUpdated or ItemDeleted
+	AggregateType string          `json:"aggregate_type" gorm:"size:50;not null"` // e.g. item
+	AggregateID   int64           `json:"aggregate_id" gorm:"not null"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;not null" swaggertype:"object"`
+	Attempts      int             `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" gorm:"not null"`
+	LastError     string          `json:"last_error,omitempty" gorm:"not null;default:''"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryDead      = "dead" // Gave up after the maximum number of attempts
+)
+
+// WebhookSubscription is an integrator's endpoint that receives signed item events.
+// The secret is returned only when the subscription is created.
+type WebhookSubscription struct {
+	ID         int64     `json:"id" gorm:"primaryKey"`
+	URL        string    `json:"url" gorm:"size:2048;not null"`
+	EventTypes []string  `json:"event_types" gorm:"type:jsonb;serializer:json;not null"`
+	Secret     string    `json:"-" gorm:"size:255;not null"`
+	Active     bool      `json:"active" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery is one event to be sent to one subscription
+type WebhookDelivery struct {
+	ID             int64           `json:"id" gorm:"primaryKey"`
+	SubscriptionID int64           `json:"subscription_id" gorm:"not null"`
+	EventID        int64           `json:"event_id" gorm:"not null"` // events.Event ID, the same for every subscription
+	EventType      string          `json:"event_type" gorm:"size:50;not null"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb;not null" swaggertype:"object"` // The request body
+	Status         string          `json:"status" gorm:"size:20;not null"`                          // pending, succeeded or dead
+	Attempts       int             `json:"attempts" gorm:"not null"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" gorm:"not null"`
+	LastError      string          `json:"last_error,omitempty" gorm:"not null"`
+	ResponseStatus int             `json:"response_status,omitempty" gorm:"not null"` // Of the last attempt
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+
+	Subscription *WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID"`
+	History      []WebhookAttempt     `json:"history,omitempty" gorm:"foreignKey:DeliveryID"` // Only loaded for a single delivery
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookAttempt records one attempt to send a delivery
+type WebhookAttempt struct {
+	ID             int64     `json:"id" gorm:"primaryKey"`
+	DeliveryID     int64     `json:"delivery_id" gorm:"not null"`
+	Attempt        int       `json:"attempt" gorm:"not null"`
+	ResponseStatus int       `json:"response_status,omitempty" gorm:"not null"` // 0 if no response was received
+	Error          string    `json:"error,omitempty" gorm:"not null"`
+	DurationMs     int64     `json:"duration_ms" gorm:"not null"`
+	AttemptedAt    time.Time `json:"attempted_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// CreateWebhookRequest represents the request body for creating a webhook subscription
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=ItemCreated ItemUpdated ItemDeleted"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16,max=255"` // Generated when empty
+	Active     *bool    `json:"active"`                                    // Defaults to true
+}
+
+// UpdateWebhookRequest represents the request body for replacing a webhook subscription
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=ItemCreated ItemUpdated ItemDeleted"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16,max=255"` // Rotates the secret when set
+	Active     *bool    `json:"active"`                                    // Unchanged when omitted
+}
+
+// CreateWebhookResponse is returned once on creation and contains the signing secret
+type CreateWebhookResponse struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// ListWebhookDeliveriesQuery represents the query parameters for listing deliveries
+type ListWebhookDeliveriesQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// WebhookDeliveryPage represents one page of deliveries, newest first
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
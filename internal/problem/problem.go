@@ -0,0 +1,126 @@
+// Package problem writes RFC 9457 problem details (application/problem+json)
+// with stable, machine-readable error codes from a central catalog.
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+)
+
+// ContentType is the media type of problem details responses
+const ContentType = "application/problem+json"
+
+// TypeBaseURI prefixes a code to form the type URI of its problems. Point it
+// at the published documentation of the error codes.
+const TypeBaseURI = "https://example.com/problems/"
+
+// Code identifies a kind of problem. Codes are part of the API contract:
+// clients branch on them, so never change or reuse one.
+type Code string
+
+// Error codes; see catalog for their status and title
+const (
+	CodeInvalidRequest       Code = "invalid_request"
+	CodeValidationFailed     Code = "validation_failed"
+	CodeInvalidID            Code = "invalid_id"
+	CodeUnauthenticated      Code = "unauthenticated"
+	CodeInvalidCredentials   Code = "invalid_credentials"
+	CodeInvalidToken         Code = "invalid_token"
+	CodeTokenExpired         Code = "token_expired"
+	CodeInvalidAPIKey        Code = "invalid_api_key"
+	CodeForbidden            Code = "forbidden"
+	CodeNotFound             Code = "not_found"
+	CodePatchConflict        Code = "patch_conflict"
+	CodeVersionMismatch      Code = "version_mismatch"
+	CodePayloadTooLarge      Code = "payload_too_large"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+	CodePatchResultInvalid   Code = "patch_result_invalid"
+	CodePreconditionRequired Code = "precondition_required"
+	CodeInternal             Code = "internal_error"
+)
+
+// definition is the status and title shared by all problems of a code
+type definition struct {
+	status int
+	title  string
+}
+
+// catalog defines every code. The title is a short summary of the kind of
+// problem, translated by Respond; details of an occurrence belong in Details.Detail.
+var catalog = map[Code]definition{
+	CodeInvalidRequest:       {http.StatusBadRequest, "Malformed request"},
+	CodeValidationFailed:     {http.StatusBadRequest, "Validation failed"},
+	CodeInvalidID:            {http.StatusBadRequest, "Invalid ID"},
+	CodeUnauthenticated:      {http.StatusUnauthorized, "Authentication required"},
+	CodeInvalidCredentials:   {http.StatusUnauthorized, "Invalid credentials"},
+	CodeInvalidToken:         {http.StatusUnauthorized, "Invalid token"},
+	CodeTokenExpired:         {http.StatusUnauthorized, "Token expired"},
+	CodeInvalidAPIKey:        {http.StatusUnauthorized, "Invalid API key"},
+	CodeForbidden:            {http.StatusForbidden, "Insufficient permissions"},
+	CodeNotFound:             {http.StatusNotFound, "Resource not found"},
+	CodePatchConflict:        {http.StatusConflict, "Patch cannot be applied"},
+	CodeVersionMismatch:      {http.StatusPreconditionFailed, "Version mismatch"},
+	CodePayloadTooLarge:      {http.StatusRequestEntityTooLarge, "Payload too large"},
+	CodeUnsupportedMediaType: {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	CodePatchResultInvalid:   {http.StatusUnprocessableEntity, "Patched resource is invalid"},
+	CodePreconditionRequired: {http.StatusPreconditionRequired, "Precondition required"},
+	CodeInternal:             {http.StatusInternalServerError, "Internal server error"},
+}
+
+// Details is an RFC 9457 problem details object. Code, RequestID and Errors
+// are extension members.
+type Details struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      Code         `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"` // Invalid fields of validation problems
+}
+
+// FieldError is one failed validation rule
+type FieldError struct {
+	Field   string `json:"field"`   // JSON path or query parameter, e.g. "operations[0].name"
+	Rule    string `json:"rule"`    // Failed validation tag, e.g. "required", or "type"
+	Message string `json:"message"` // e.g. "name is required"
+}
+
+// New returns a problem of code with detail describing this occurrence, already
+// in the client's language (see i18n.T). Unknown codes are reported as internal errors.
+func New(code Code, detail string) *Details {
+	def, ok := catalog[code]
+	if !ok {
+		code, def = CodeInternal, catalog[CodeInternal]
+	}
+	return &Details{
+		Type:   TypeBaseURI + string(code),
+		Title:  def.title,
+		Status: def.status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Respond writes p as the response, with its title in the request's locale,
+// the request path as its instance and the request ID of the context
+func Respond(c *gin.Context, p *Details) {
+	ctx := c.Request.Context()
+	p.Title = i18n.T(ctx, p.Title)
+	p.Instance = c.Request.URL.Path
+	p.RequestID = requestid.FromContext(ctx)
+	// Render keeps a Content-Type that is already set
+	c.Header("Content-Type", ContentType)
+	c.JSON(p.Status, p)
+}
+
+// Abort writes p like Respond and stops the remaining handlers
+func Abort(c *gin.Context, p *Details) {
+	Respond(c, p)
+	c.Abort()
+}
@@ -0,0 +1,82 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+)
+
+// =============================================================================
+// New Tests
+// =============================================================================
+
+func TestNew_UsesCatalog(t *testing.T) {
+	p := New(CodeNotFound, "Item not found")
+
+	want := Details{
+		Type:   "https://example.com/problems/not_found",
+		Title:  "Resource not found",
+		Status: http.StatusNotFound,
+		Detail: "Item not found",
+		Code:   CodeNotFound,
+	}
+	if p.Type != want.Type || p.Title != want.Title || p.Status != want.Status || p.Detail != want.Detail || p.Code != want.Code {
+		t.Errorf("New() = %+v, want %+v", p, want)
+	}
+}
+
+func TestNew_ReportsUnknownCodesAsInternal(t *testing.T) {
+	p := New(Code("no_such_code"), "Something broke")
+
+	if p.Code != CodeInternal || p.Status != http.StatusInternalServerError || p.Detail != "Something broke" {
+		t.Errorf("New() = %+v, want internal_error keeping the detail", p)
+	}
+}
+
+func TestCatalog_DefinesStatusAndTitle(t *testing.T) {
+	for code, def := range catalog {
+		if def.status < 400 || def.status > 599 || def.title == "" {
+			t.Errorf("catalog[%s] = %+v, want an error status and a title", code, def)
+		}
+	}
+}
+
+// =============================================================================
+// Respond Tests
+// =============================================================================
+
+func TestAbort_WritesProblemJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reached := false
+
+	router := gin.New()
+	router.Use(requestid.Middleware())
+	router.GET("/items/:id",
+		func(c *gin.Context) { Abort(c, New(CodeForbidden, "Requires one of roles: admin")) },
+		func(*gin.Context) { reached = true },
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7?expand=all", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden || w.Header().Get("Content-Type") != ContentType {
+		t.Errorf("response = %d %q, want 403 %q", w.Code, w.Header().Get("Content-Type"), ContentType)
+	}
+	var body Details
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response body %s: %v", w.Body.String(), err)
+	}
+	if body.Instance != "/items/7" || body.RequestID != "req-1" || body.Code != CodeForbidden {
+		t.Errorf("body = %+v, want instance /items/7 and request_id req-1", body)
+	}
+	if reached {
+		t.Error("Abort() should stop later handlers")
+	}
+}
@@ -0,0 +1,155 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+)
+
+// init names fields in validation errors after their JSON or query parameter
+// names, as clients know them. It must run before any struct is validated,
+// since the validator caches field names per type.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName returns the JSON, query or path parameter name of a struct field
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Binding returns the problem of a request that failed to bind: validation_failed
+// listing the invalid fields, or invalid_request for a malformed body or query.
+// Messages are in the locale of ctx. The raw error text, which names Go types
+// and validator internals, is not exposed.
+func Binding(ctx context.Context, err error) *Details {
+	if fields := FieldErrors(ctx, err); len(fields) > 0 {
+		return Invalid(CodeValidationFailed, fields)
+	}
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF):
+		return New(CodeInvalidRequest, i18n.T(ctx, "Request body is empty"))
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return New(CodeInvalidRequest, i18n.T(ctx, "Request body is not valid JSON"))
+	default:
+		return New(CodeInvalidRequest, i18n.T(ctx, "Request body or query could not be parsed"))
+	}
+}
+
+// Invalid returns a problem of code listing the invalid fields, with their
+// messages as detail
+func Invalid(code Code, fields []FieldError) *Details {
+	p := New(code, Message(fields))
+	p.Errors = fields
+	return p
+}
+
+// FieldErrors translates validation failures and JSON values of the wrong type
+// into field errors with messages in the locale of ctx. Other errors have none.
+func FieldErrors(ctx context.Context, err error) []FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: i18n.T(ctx, "{0} must be {1}", typeErr.Field, i18n.T(ctx, jsonType(typeErr.Type))),
+		}}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		// The namespace starts with the Go name of the validated struct
+		_, path, found := strings.Cut(fe.Namespace(), ".")
+		if !found {
+			path = fe.Field()
+		}
+		fields = append(fields, FieldError{Field: path, Rule: fe.Tag(), Message: message(ctx, fe)})
+	}
+	return fields
+}
+
+// Message joins the messages of field errors, e.g. "name is required; limit must be at most 100"
+func Message(fields []FieldError) string {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// message describes a failed validation rule in plain words
+func message(ctx context.Context, fe validator.FieldError) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return i18n.T(ctx, "{0} is required", field)
+	case "excluded_if", "excluded_unless", "excluded_with", "excluded_without":
+		return i18n.T(ctx, "{0} must not be set", field)
+	case "min":
+		return i18n.T(ctx, "{0} must be at least {1}", field, bound(ctx, fe))
+	case "max":
+		return i18n.T(ctx, "{0} must be at most {1}", field, bound(ctx, fe))
+	case "oneof":
+		return i18n.T(ctx, "{0} must be one of: {1}", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "url", "http_url":
+		return i18n.T(ctx, "{0} must be a valid URL", field)
+	case "excludesall":
+		return i18n.T(ctx, "{0} must not contain any of {1}", field, strconv.Quote(fe.Param()))
+	default:
+		return i18n.T(ctx, "{0} does not satisfy {1}", field, fe.Tag())
+	}
+}
+
+// bound describes the parameter of a min or max rule for the kind of field
+func bound(ctx context.Context, fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return i18n.Plural(ctx, "{0} characters", fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return i18n.Plural(ctx, "{0} items", fe.Param())
+	default:
+		return fe.Param()
+	}
+}
+
+// jsonType names the JSON type a Go type is decoded from, with an article,
+// as a message key
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,92 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// validated has one field per translated rule
+type validated struct {
+	Name     string   `json:"name" binding:"required,max=5"`
+	Tags     []string `json:"tags" binding:"min=1"`
+	Limit    int      `form:"limit" binding:"max=100"`
+	Mode     string   `json:"mode" binding:"oneof=fast safe"`
+	Internal string   `json:"-" binding:"excluded_if=Mode fast"`
+	Code     string   `json:"code" binding:"excludesall= ,len=3"`
+}
+
+// validate returns the field errors of v
+func validate(t *testing.T, v *validated) map[string]FieldError {
+	t.Helper()
+	err := binding.Validator.ValidateStruct(v)
+	if err == nil {
+		t.Fatal("ValidateStruct() should fail")
+	}
+	fields := make(map[string]FieldError)
+	for _, field := range FieldErrors(context.Background(), err) {
+		fields[field.Field] = field
+	}
+	return fields
+}
+
+// =============================================================================
+// FieldErrors Tests
+// =============================================================================
+
+func TestFieldErrors_TranslatesRules(t *testing.T) {
+	fields := validate(t, &validated{
+		Name:     "too long",
+		Limit:    101,
+		Mode:     "fast",
+		Internal: "set",
+		Code:     "a b",
+	})
+
+	tests := []struct {
+		field   string
+		rule    string
+		message string
+	}{
+		{field: "name", rule: "max", message: "name must be at most 5 characters"},
+		{field: "tags", rule: "min", message: "tags must be at least 1 item"},
+		{field: "limit", rule: "max", message: "limit must be at most 100"},
+		{field: "Internal", rule: "excluded_if", message: "Internal must not be set"},
+		{field: "code", rule: "excludesall", message: `code must not contain any of " "`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, ok := fields[tt.field]
+			if !ok {
+				t.Fatalf("no error for %s in %+v", tt.field, fields)
+			}
+			if got.Rule != tt.rule || got.Message != tt.message {
+				t.Errorf("%s = %+v, want rule %q and message %q", tt.field, got, tt.rule, tt.message)
+			}
+		})
+	}
+}
+
+func TestFieldErrors_DescribesOtherRulesAndEnums(t *testing.T) {
+	fields := validate(t, &validated{Name: "ok", Tags: []string{"a"}, Mode: "slow", Code: "ab"})
+
+	if got := fields["mode"].Message; got != "mode must be one of: fast, safe" {
+		t.Errorf("mode message = %q", got)
+	}
+	if got := fields["code"].Message; got != "code does not satisfy len" {
+		t.Errorf("code message = %q", got)
+	}
+}
+
+func TestFieldErrors_NoneForOtherErrors(t *testing.T) {
+	if fields := FieldErrors(context.Background(), errors.New("boom")); fields != nil {
+		t.Errorf("FieldErrors() = %+v, want none", fields)
+	}
+}
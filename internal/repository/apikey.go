@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// CreateAPIKey stores a new API key
+func (r *repository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	err := r.db.WithContext(ctx).
+		Omit("ID", "CreatedAt", "LastUsedAt", "RevokedAt").
+		Create(key).Error
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// ListAPIKeys retrieves all API keys, newest first
+func (r *repository) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Find(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its plaintext value
+func (r *repository) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.WithContext(ctx).
+		Where("key_hash = ?", hash).
+		First(&key).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// TouchAPIKey records the time an API key was last used
+func (r *repository) TouchAPIKey(ctx context.Context, id int64, usedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+	return nil
+}
+
+// RevokeAPIKey revokes an active API key by ID
+func (r *repository) RevokeAPIKey(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if err := checkRowsAffected(result); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GunarsK-templates/template-api/internal/audit"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// AuditEntityItem is the entity type of item audit events
+const AuditEntityItem = "item"
+
+// AuditListOptions controls filtering and pagination of ListAuditEvents.
+// Zero values do not filter.
+type AuditListOptions struct {
+	EntityType string
+	EntityID   int64
+	Action     string
+	Actor      string
+	RequestID  string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+// itemChange is one item mutation to audit; before is nil for creates, after for purges
+type itemChange struct {
+	id     int64
+	before *models.Item
+	after  *models.Item
+}
+
+// ListAuditEvents retrieves a page of audit events, newest first
+func (r *repository) ListAuditEvents(ctx context.Context, opts AuditListOptions) (*models.AuditPage, error) {
+	if opts.Limit <= 0 || opts.Limit > MaxListLimit {
+		opts.Limit = DefaultListLimit
+	}
+
+	query := r.db.WithContext(ctx).Model(&models.AuditEvent{})
+	if opts.EntityType != "" {
+		query = query.Where("entity_type = ?", opts.EntityType)
+	}
+	if opts.EntityID != 0 {
+		query = query.Where("entity_id = ?", opts.EntityID)
+	}
+	if opts.Action != "" {
+		query = query.Where("action = ?", opts.Action)
+	}
+	if opts.Actor != "" {
+		query = query.Where("actor = ?", opts.Actor)
+	}
+	if opts.RequestID != "" {
+		query = query.Where("request_id = ?", opts.RequestID)
+	}
+	if opts.Since != nil {
+		query = query.Where("created_at >= ?", *opts.Since)
+	}
+	if opts.Until != nil {
+		query = query.Where("created_at < ?", *opts.Until)
+	}
+
+	page := &models.AuditPage{Events: []models.AuditEvent{}}
+	err := query.
+		Order("id DESC").
+		Limit(opts.Limit).
+		Offset(opts.Offset).
+		Find(&page.Events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return page, nil
+}
+
+// recordItemEvents records audit events for item changes with the actor and request ID
+// from ctx, and adds their domain events to the outbox. tx must be the transaction
+// that made the changes so everything commits together.
+func recordItemEvents(ctx context.Context, tx *gorm.DB, action string, changes ...itemChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	meta := audit.FromContext(ctx)
+	events := make([]models.AuditEvent, len(changes))
+	for i, change := range changes {
+		diff, err := audit.Diff(change.before, change.after)
+		if err != nil {
+			return err
+		}
+		events[i] = models.AuditEvent{
+			EntityType: AuditEntityItem,
+			EntityID:   change.id,
+			Action:     action,
+			Actor:      meta.Actor,
+			RequestID:  meta.RequestID,
+			Diff:       diff,
+		}
+	}
+
+	if err := tx.Omit("ID").CreateInBatches(events, createBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to record audit events: %w", err)
+	}
+	return enqueueItemEvents(tx, action, changes)
+}
+
+// lockItem reads an item and locks its row until the end of tx.
+// Use tx.Unscoped() to lock items in the trash.
+func lockItem(tx *gorm.DB, id int64) (*models.Item, error) {
+	var item models.Item
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// GetUserByUsername retrieves a user by username
+func (r *repository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).
+		Where("username = ?", username).
+		First(&user).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by username: %w", err)
+	}
+	return &user, nil
+}
+
+// GetUserByID retrieves a user by ID
+func (r *repository) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).
+		First(&user, id).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by id %d: %w", id, err)
+	}
+	return &user, nil
+}
+
+// CreateUser stores a new user
+func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
+	err := r.db.WithContext(ctx).
+		Omit("ID", "CreatedAt", "UpdatedAt").
+		Create(user).Error
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	return nil
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *repository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	err := r.db.WithContext(ctx).
+		Omit("ID", "CreatedAt").
+		Create(token).Error
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by its hash
+func (r *repository) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ?", hash).
+		First(&token).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken revokes the current token and stores its successor in one transaction.
+// Returns ErrRefreshTokenReused if the current token was already revoked,
+// e.g. by a concurrent rotation.
+func (r *repository) RotateRefreshToken(ctx context.Context, current, next *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		if err := tx.Omit("ID", "CreatedAt").Create(next).Error; err != nil {
+			return fmt.Errorf("failed to create refresh token: %w", err)
+		}
+		return nil
+	})
+}
+
+// RevokeRefreshTokenFamily revokes every active token in a family
+func (r *repository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GunarsK-templates/template-api/internal/audit"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// createBatchSize is the number of rows CreateItems inserts per statement
+const createBatchSize = 100
+
+// ItemDelete identifies an item for DeleteItems.
+// When Version is set the item is only deleted if it is still at that version.
+type ItemDelete struct {
+	ID      int64
+	Version *int64
+}
+
+// Transaction runs fn with a Repository bound to a database transaction.
+// The transaction commits if fn returns nil and rolls back otherwise.
+func (r *repository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&repository{db: tx})
+	})
+}
+
+// CreateItems inserts items in batches of createBatchSize and records their audit events.
+// The stored rows are read back into items.
+func (r *repository) CreateItems(ctx context.Context, items []*models.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("ID", "CreatedAt", "UpdatedAt").
+			Clauses(clause.Returning{}).
+			CreateInBatches(items, createBatchSize).Error
+		if err != nil {
+			return err
+		}
+
+		changes := make([]itemChange, len(items))
+		for i, item := range items {
+			changes[i] = itemChange{id: item.ID, after: item}
+		}
+		return recordItemEvents(ctx, tx, audit.ActionCreate, changes...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create items: %w", err)
+	}
+	return nil
+}
+
+// DeleteItems moves items to the trash in one statement and records their audit events.
+// It returns the outcome of each delete in order: nil, gorm.ErrRecordNotFound
+// or ErrVersionMismatch. IDs must be unique.
+func (r *repository) DeleteItems(ctx context.Context, deletes []ItemDelete) ([]error, error) {
+	if len(deletes) == 0 {
+		return nil, nil
+	}
+
+	var ids []int64
+	var idVersions [][]interface{}
+	for _, d := range deletes {
+		if d.Version == nil {
+			ids = append(ids, d.ID)
+		} else {
+			idVersions = append(idVersions, []interface{}{d.ID, *d.Version})
+		}
+	}
+
+	var conditions []string
+	args := []interface{}{time.Now()}
+	if len(ids) > 0 {
+		conditions = append(conditions, "id IN ?")
+		args = append(args, ids)
+	}
+	if len(idVersions) > 0 {
+		conditions = append(conditions, "(id, version) IN ?")
+		args = append(args, idVersions)
+	}
+
+	// Raw SQL because GORM's soft delete does not support RETURNING
+	var deleted []models.Item
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw("UPDATE items SET deleted_at = ? WHERE deleted_at IS NULL AND ("+
+			strings.Join(conditions, " OR ")+") RETURNING *", args...).
+			Scan(&deleted).Error
+		if err != nil {
+			return err
+		}
+
+		changes := make([]itemChange, len(deleted))
+		for i := range deleted {
+			before := deleted[i]
+			before.DeletedAt = gorm.DeletedAt{}
+			changes[i] = itemChange{id: before.ID, before: &before, after: &deleted[i]}
+		}
+		return recordItemEvents(ctx, tx, audit.ActionDelete, changes...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete items: %w", err)
+	}
+
+	isDeleted := make(map[int64]bool, len(deleted))
+	for _, item := range deleted {
+		isDeleted[item.ID] = true
+	}
+	var missing []int64
+	for _, d := range deletes {
+		if !isDeleted[d.ID] {
+			missing = append(missing, d.ID)
+		}
+	}
+
+	// Items that still exist were skipped because of their version
+	exists := make(map[int64]bool)
+	if len(missing) > 0 {
+		var existing []int64
+		err := r.db.WithContext(ctx).
+			Model(&models.Item{}).
+			Where("id IN ?", missing).
+			Pluck("id", &existing).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to check item existence: %w", err)
+		}
+		for _, id := range existing {
+			exists[id] = true
+		}
+	}
+
+	outcomes := make([]error, len(deletes))
+	for i, d := range deletes {
+		switch {
+		case isDeleted[d.ID]:
+		case exists[d.ID]:
+			outcomes[i] = ErrVersionMismatch
+		default:
+			outcomes[i] = gorm.ErrRecordNotFound
+		}
+	}
+	return outcomes, nil
+}
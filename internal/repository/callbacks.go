@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// registerAround registers callbacks of a plugin around the statement of every
+// GORM operation (create, query, update, delete, row, raw), named e.g.
+// "metrics:before_query". before and after return the callback for an operation.
+func registerAround(db *gorm.DB, plugin string, before, after func(operation string) func(*gorm.DB)) error {
+	callbacks := db.Callback()
+	name := func(when, operation string) string {
+		return plugin + ":" + when + "_" + operation
+	}
+
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register(name("before", "create"), before("create")),
+		callbacks.Create().After("gorm:create").Register(name("after", "create"), after("create")),
+		callbacks.Query().Before("gorm:query").Register(name("before", "query"), before("query")),
+		callbacks.Query().After("gorm:query").Register(name("after", "query"), after("query")),
+		callbacks.Update().Before("gorm:update").Register(name("before", "update"), before("update")),
+		callbacks.Update().After("gorm:update").Register(name("after", "update"), after("update")),
+		callbacks.Delete().Before("gorm:delete").Register(name("before", "delete"), before("delete")),
+		callbacks.Delete().After("gorm:delete").Register(name("after", "delete"), after("delete")),
+		callbacks.Row().Before("gorm:row").Register(name("before", "row"), before("row")),
+		callbacks.Row().After("gorm:row").Register(name("after", "row"), after("row")),
+		callbacks.Raw().Before("gorm:raw").Register(name("before", "raw"), before("raw")),
+		callbacks.Raw().After("gorm:raw").Register(name("after", "raw"), after("raw")),
+	)
+}
@@ -4,3 +4,9 @@ import "errors"
 
 // ErrNotFound is returned when a resource is not found
 var ErrNotFound = errors.New("resource not found")
+
+// ErrVersionMismatch is returned when a conditional write finds a different version than expected
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again
+var ErrRefreshTokenReused = errors.New("refresh token reused")
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// streamBatchSize is how many items StreamItems reads per query
+const streamBatchSize = 500
+
+// StreamItems calls fn for every item in ID order. Items are read in keyset
+// batches, so the table is never held in memory and no connection is held
+// while fn runs (e.g. writing to a slow client). Items created during the
+// iteration may be included. Iteration stops at the first error from fn.
+func (r *repository) StreamItems(ctx context.Context, fn func(item *models.Item) error) error {
+	var lastID int64
+	for {
+		var batch []models.Item
+		err := r.db.WithContext(ctx).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(streamBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return fmt.Errorf("failed to stream items: %w", err)
+		}
+
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		if len(batch) < streamBatchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
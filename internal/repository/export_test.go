@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// StreamItems Tests
+// =============================================================================
+
+func TestStreamItems_ReadsKeysetBatches(t *testing.T) {
+	db, _ := dryRunDB(t)
+	var statements []string
+	capture := func(tx *gorm.DB) {
+		statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("test:capture", capture); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// Dry run queries return no rows, so iteration ends after the first batch
+	calls := 0
+	err := New(db).StreamItems(context.Background(), func(*models.Item) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamItems() error = %v", err)
+	}
+
+	if len(statements) != 1 || calls != 0 {
+		t.Fatalf("statements = %q, calls = %d, want one query and no items", statements, calls)
+	}
+	for _, want := range []string{"id > 0", `"items"."deleted_at" IS NULL`, "ORDER BY id LIMIT 500"} {
+		if !strings.Contains(statements[0], want) {
+			t.Errorf("statement = %s, want %s", statements[0], want)
+		}
+	}
+}
@@ -3,20 +3,83 @@ package repository
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GunarsK-templates/template-api/internal/audit"
 	"github.com/GunarsK-templates/template-api/internal/models"
 )
 
-// GetAllItems retrieves all items
-func (r *repository) GetAllItems(ctx context.Context) ([]models.Item, error) {
-	var items []models.Item
-	err := r.db.WithContext(ctx).
-		Order("created_at DESC").
-		Find(&items).Error
+// ItemSortFields lists the columns items can be sorted by
+var ItemSortFields = []string{"id", "name", "created_at", "updated_at"}
+
+// ItemListOptions controls filtering, ordering and pagination of ListItems
+type ItemListOptions struct {
+	Limit         int
+	Offset        int
+	Sort          []SortField // Defaults to created_at descending
+	After         *Cursor     // Requires a sort accepted by KeysetDirection
+	NameContains  string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	IncludeTotal  bool
+}
+
+// ListItems retrieves a page of items
+func (r *repository) ListItems(ctx context.Context, opts ItemListOptions) (*models.ItemPage, error) {
+	if opts.Limit <= 0 || opts.Limit > MaxListLimit {
+		opts.Limit = DefaultListLimit
+	}
+	if len(opts.Sort) == 0 {
+		opts.Sort = []SortField{{Column: "created_at", Desc: true}}
+	}
+	desc, keyset := KeysetDirection(opts.Sort)
+	if opts.After != nil && !keyset {
+		return nil, fmt.Errorf("cursor requires sorting by created_at: %w", ErrInvalidCursor)
+	}
+
+	query := r.db.WithContext(ctx).Model(&models.Item{})
+	if opts.NameContains != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(opts.NameContains)+"%")
+	}
+	if opts.CreatedAfter != nil {
+		query = query.Where("created_at > ?", *opts.CreatedAfter)
+	}
+	if opts.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *opts.CreatedBefore)
+	}
+	// Filters are shared by the count and the page query
+	query = query.Session(&gorm.Session{})
+
+	page := &models.ItemPage{Items: []models.Item{}}
+	if opts.IncludeTotal {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, fmt.Errorf("failed to count items: %w", err)
+		}
+		page.Total = &total
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	err := applyOrder(applyCursor(query, opts.After, desc), opts.Sort).
+		Limit(opts.Limit + 1).
+		Offset(opts.Offset).
+		Find(&page.Items).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all items: %w", err)
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+
+	if len(page.Items) > opts.Limit {
+		page.Items = page.Items[:opts.Limit]
+		if keyset {
+			last := page.Items[len(page.Items)-1]
+			page.NextCursor = Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+		}
 	}
-	return items, nil
+	return page, nil
 }
 
 // GetItemByID retrieves an item by its ID
@@ -30,47 +93,98 @@ func (r *repository) GetItemByID(ctx context.Context, id int64) (*models.Item, e
 	return &item, nil
 }
 
-// CreateItem creates a new item
+// CreateItem creates a new item and records its audit event.
+// The stored row is read back into item.
 func (r *repository) CreateItem(ctx context.Context, item *models.Item) error {
-	err := r.db.WithContext(ctx).
-		Omit("ID", "CreatedAt", "UpdatedAt").
-		Create(item).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("ID", "CreatedAt", "UpdatedAt").
+			Clauses(clause.Returning{}).
+			Create(item).Error
+		if err != nil {
+			return err
+		}
+		return recordItemEvents(ctx, tx, audit.ActionCreate, itemChange{id: item.ID, after: item})
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create item: %w", err)
 	}
 	return nil
 }
 
-// UpdateItem updates an existing item
-func (r *repository) UpdateItem(ctx context.Context, item *models.Item) error {
-	// First check if record exists
-	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.Item{}).Where("id = ?", item.ID).Count(&count).Error; err != nil {
-		return fmt.Errorf("failed to check item existence: %w", err)
-	}
-	if count == 0 {
-		return fmt.Errorf("item not found: %w", ErrNotFound)
+// UpdateItem updates an existing item and increments its version.
+// When ifVersions is non-nil the update only applies if the current version is one of them,
+// otherwise ErrVersionMismatch is returned. The stored row is read back into item.
+func (r *repository) UpdateItem(ctx context.Context, item *models.Item, ifVersions []int64) error {
+	if err := r.updateItemColumns(ctx, item, []string{"name", "description"}, ifVersions); err != nil {
+		return fmt.Errorf("failed to update item: %w", err)
 	}
+	return nil
+}
 
-	// Update the record
-	err := r.db.WithContext(ctx).
-		Model(&models.Item{}).
-		Where("id = ?", item.ID).
-		Omit("ID", "CreatedAt").
-		Updates(map[string]interface{}{
-			"name":        item.Name,
-			"description": item.Description,
-		}).Error
-	if err != nil {
-		return fmt.Errorf("failed to update item: %w", err)
+// PatchItem writes only the given columns of item and increments its version.
+// Version checks and read-back behave as in UpdateItem.
+func (r *repository) PatchItem(ctx context.Context, item *models.Item, columns []string, ifVersions []int64) error {
+	if err := r.updateItemColumns(ctx, item, columns, ifVersions); err != nil {
+		return fmt.Errorf("failed to patch item: %w", err)
 	}
 	return nil
 }
 
-// DeleteItem deletes an item by ID
-func (r *repository) DeleteItem(ctx context.Context, id int64) error {
-	result := r.db.WithContext(ctx).Delete(&models.Item{}, id)
-	if err := checkRowsAffected(result); err != nil {
+// updateItemColumns writes the given editable columns of item under a row lock,
+// checking the version and recording the audit event in the same transaction
+func (r *repository) updateItemColumns(ctx context.Context, item *models.Item, columns []string, ifVersions []int64) error {
+	updates := map[string]interface{}{
+		"version":    gorm.Expr("version + 1"),
+		"updated_at": time.Now(),
+	}
+	for _, column := range columns {
+		switch column {
+		case "name":
+			updates[column] = item.Name
+		case "description":
+			updates[column] = item.Description
+		default:
+			return fmt.Errorf("column %q is not editable", column)
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := lockItem(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if ifVersions != nil && !slices.Contains(ifVersions, before.Version) {
+			return ErrVersionMismatch
+		}
+
+		if err := tx.Model(item).Clauses(clause.Returning{}).Updates(updates).Error; err != nil {
+			return err
+		}
+		return recordItemEvents(ctx, tx, audit.ActionUpdate, itemChange{id: item.ID, before: before, after: item})
+	})
+}
+
+// DeleteItem moves an item to the trash by setting deleted_at.
+// When ifVersions is non-nil the delete only applies if the current version is one of them,
+// otherwise ErrVersionMismatch is returned.
+func (r *repository) DeleteItem(ctx context.Context, id int64, ifVersions []int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := lockItem(tx, id)
+		if err != nil {
+			return err
+		}
+		if ifVersions != nil && !slices.Contains(ifVersions, before.Version) {
+			return ErrVersionMismatch
+		}
+
+		after := *before
+		// UpdateColumn leaves updated_at alone, like a soft delete via Delete
+		if err := tx.Model(&after).Clauses(clause.Returning{}).UpdateColumn("deleted_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return recordItemEvents(ctx, tx, audit.ActionDelete, itemChange{id: id, before: before, after: &after})
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
 	return nil
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// List limits
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Sort errors, returned by ParseSort wrapped in a *SortError
+var (
+	ErrUnknownSortField   = errors.New("sort field not allowed")
+	ErrDuplicateSortField = errors.New("duplicate sort field")
+)
+
+// SortError is a sort field rejected by ParseSort
+type SortError struct {
+	Err     error // ErrUnknownSortField or ErrDuplicateSortField
+	Field   string
+	Allowed []string
+}
+
+func (e *SortError) Error() string {
+	if errors.Is(e.Err, ErrDuplicateSortField) {
+		return fmt.Sprintf("duplicate sort field %q", e.Field)
+	}
+	return fmt.Sprintf("cannot sort by %q (allowed: %s)", e.Field, strings.Join(e.Allowed, ", "))
+}
+
+func (e *SortError) Unwrap() error {
+	return e.Err
+}
+
+// SortField is a whitelisted column to order by
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// ParseSort parses a comma-separated sort expression such as "name,-created_at".
+// A leading "-" sorts descending. Only columns in allowed are accepted.
+func ParseSort(raw string, allowed []string) ([]SortField, error) {
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Column: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !slices.Contains(allowed, field.Column) {
+			return nil, &SortError{Err: ErrUnknownSortField, Field: field.Column, Allowed: allowed}
+		}
+		if seen[field.Column] {
+			return nil, &SortError{Err: ErrDuplicateSortField, Field: field.Column, Allowed: allowed}
+		}
+		seen[field.Column] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Cursor marks the last row of a page for keyset pagination on (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int64     `json:"i"`
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	// A struct of a time and an int always marshals
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// KeysetDirection reports whether sort can be paginated with a Cursor,
+// i.e. it orders by created_at (optionally followed by id in the same direction).
+// desc is the direction of that ordering.
+func KeysetDirection(sort []SortField) (desc, ok bool) {
+	switch {
+	case len(sort) == 1 && sort[0].Column == "created_at":
+		return sort[0].Desc, true
+	case len(sort) == 2 && sort[0].Column == "created_at" && sort[1].Column == "id" && sort[1].Desc == sort[0].Desc:
+		return sort[0].Desc, true
+	default:
+		return false, false
+	}
+}
+
+// applyOrder orders query by sort, appending id as a tiebreaker so pages are stable
+func applyOrder(query *gorm.DB, sort []SortField) *gorm.DB {
+	hasID := false
+	for _, field := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+		hasID = hasID || field.Column == "id"
+	}
+	if !hasID {
+		desc := len(sort) > 0 && sort[len(sort)-1].Desc
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc})
+	}
+	return query
+}
+
+// applyCursor restricts query to rows after the cursor in keyset order
+func applyCursor(query *gorm.DB, after *Cursor, desc bool) *gorm.DB {
+	if after == nil {
+		return query
+	}
+	if desc {
+		return query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+	return query.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
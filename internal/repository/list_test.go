@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// ParseSort Tests
+// =============================================================================
+
+func TestParseSort_TableDriven(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []SortField
+		wantErr string
+	}{
+		{
+			name: "empty",
+			raw:  "",
+			want: nil,
+		},
+		{
+			name: "single ascending",
+			raw:  "name",
+			want: []SortField{{Column: "name"}},
+		},
+		{
+			name: "mixed directions with spaces",
+			raw:  "name, -created_at",
+			want: []SortField{{Column: "name"}, {Column: "created_at", Desc: true}},
+		},
+		{
+			name: "ignores empty parts",
+			raw:  ",-id,",
+			want: []SortField{{Column: "id", Desc: true}},
+		},
+		{
+			name:    "not whitelisted",
+			raw:     "password_hash",
+			wantErr: "cannot sort by",
+		},
+		{
+			name:    "sql injection attempt",
+			raw:     "name;DROP TABLE items",
+			wantErr: "cannot sort by",
+		},
+		{
+			name:    "duplicate column",
+			raw:     "name,-name",
+			wantErr: "duplicate sort field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.raw, ItemSortFields)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseSort() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSort() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// Cursor Tests
+// =============================================================================
+
+func TestCursor_RoundTrip(t *testing.T) {
+	want := Cursor{CreatedAt: time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC), ID: 42}
+
+	got, err := DecodeCursor(want.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("DecodeCursor() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeCursor_RejectsInvalid(t *testing.T) {
+	inputs := []string{
+		"not base64!",
+		Cursor{}.Encode(),
+		Cursor{CreatedAt: time.Now(), ID: -1}.Encode(),
+		"eyJjIjoxfQ", // {"c":1}
+	}
+
+	for _, input := range inputs {
+		if _, err := DecodeCursor(input); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", input, err)
+		}
+	}
+}
+
+// =============================================================================
+// KeysetDirection Tests
+// =============================================================================
+
+func TestKeysetDirection_TableDriven(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     []SortField
+		wantDesc bool
+		wantOK   bool
+	}{
+		{
+			name:     "created_at descending",
+			sort:     []SortField{{Column: "created_at", Desc: true}},
+			wantDesc: true,
+			wantOK:   true,
+		},
+		{
+			name:   "created_at ascending",
+			sort:   []SortField{{Column: "created_at"}},
+			wantOK: true,
+		},
+		{
+			name:     "created_at then id same direction",
+			sort:     []SortField{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			wantDesc: true,
+			wantOK:   true,
+		},
+		{
+			name: "created_at then id opposite direction",
+			sort: []SortField{{Column: "created_at", Desc: true}, {Column: "id"}},
+		},
+		{
+			name: "other column",
+			sort: []SortField{{Column: "name"}},
+		},
+		{
+			name: "created_at not first",
+			sort: []SortField{{Column: "name"}, {Column: "created_at"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, ok := KeysetDirection(tt.sort)
+			if desc != tt.wantDesc || ok != tt.wantOK {
+				t.Errorf("KeysetDirection() = (%v, %v), want (%v, %v)", desc, ok, tt.wantDesc, tt.wantOK)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// escapeLike Tests
+// =============================================================================
+
+func TestEscapeLike_EscapesWildcards(t *testing.T) {
+	got := escapeLike(`50%_off\now`)
+	want := `50\%\_off\\now`
+
+	if got != want {
+		t.Errorf("escapeLike() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// queryStartKey stores the start time of a statement in its GORM instance
+const queryStartKey = "metrics:query_start"
+
+// QueryMetrics is a GORM plugin recording the duration and errors of queries
+// by operation (create, query, update, delete, row, raw) and table.
+// Raw SQL without a model has an empty table label.
+type QueryMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewQueryMetrics registers the query metrics with reg. Add the result to a
+// connection with db.Use.
+func NewQueryMetrics(reg prometheus.Registerer, buckets []float64) *QueryMetrics {
+	m := &QueryMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database statements by operation and table.",
+			Buckets: buckets,
+		}, []string{"operation", "table"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Failed database statements by operation and table, not counting missing records.",
+		}, []string{"operation", "table"}),
+	}
+	reg.MustRegister(m.duration, m.errors)
+	return m
+}
+
+// Name implements gorm.Plugin
+func (m *QueryMetrics) Name() string {
+	return "metrics"
+}
+
+// Initialize implements gorm.Plugin by timing each processor's main callback
+func (m *QueryMetrics) Initialize(db *gorm.DB) error {
+	return registerAround(db, m.Name(), func(string) func(*gorm.DB) { return m.before }, m.after)
+}
+
+// before records when a statement starts
+func (m *QueryMetrics) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// after returns a callback observing the duration and outcome of an operation
+func (m *QueryMetrics) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		start, isTime := value.(time.Time)
+		if !ok || !isTime {
+			return
+		}
+
+		table := db.Statement.Table
+		m.duration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			m.errors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// dryRunDB opens a connection that builds statements without a database and
+// records query metrics into a fresh registry
+func dryRunDB(t *testing.T) (*gorm.DB, *prometheus.Registry) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+
+	reg := prometheus.NewRegistry()
+	if err := db.Use(NewQueryMetrics(reg, []float64{0.1, 1})); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	return db, reg
+}
+
+// failQueries makes every query fail with err
+func failQueries(t *testing.T, db *gorm.DB, err error) {
+	t.Helper()
+	fail := func(db *gorm.DB) { db.AddError(err) } //nolint:errcheck // error is kept on db
+	if err := db.Callback().Query().After("gorm:query").Before("metrics:after_query").Register("test:fail", fail); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+}
+
+// gatherSamples returns the counter values or histogram sample counts of a metric
+// by "operation/table"
+func gatherSamples(t *testing.T, reg *prometheus.Registry, name string) map[string]float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	samples := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			value := metric.GetCounter().GetValue()
+			if metric.GetHistogram() != nil {
+				value = float64(metric.GetHistogram().GetSampleCount())
+			}
+			samples[labels["operation"]+"/"+labels["table"]] = value
+		}
+	}
+	return samples
+}
+
+// =============================================================================
+// QueryMetrics Tests
+// =============================================================================
+
+func TestQueryMetrics_RecordsOperationAndTable(t *testing.T) {
+	db, reg := dryRunDB(t)
+
+	db.Find(&[]models.Item{})
+	db.Find(&[]models.Item{})
+	db.Create(&models.Item{Name: "New"})
+	db.Exec("SELECT 1")
+
+	got := gatherSamples(t, reg, "db_query_duration_seconds")
+	want := map[string]float64{"query/items": 2, "create/items": 1, "raw/": 1}
+	if len(got) != len(want) {
+		t.Fatalf("statements = %v, want %v", got, want)
+	}
+	for series, count := range want {
+		if got[series] != count {
+			t.Errorf("statements = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestQueryMetrics_CountsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want float64
+	}{
+		{name: "failed query", err: errors.New("connection reset"), want: 1},
+		{name: "missing record", err: gorm.ErrRecordNotFound, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, reg := dryRunDB(t)
+			failQueries(t, db, tt.err)
+
+			db.First(&models.Item{}, 1)
+
+			got := gatherSamples(t, reg, "db_query_errors_total")["query/items"]
+			if got != tt.want {
+				t.Errorf("db_query_errors_total = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/audit"
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+	"github.com/GunarsK-templates/template-api/internal/stream"
+)
+
+// itemEventTypes maps audited item actions to the domain event they publish.
+// Purges publish nothing: the item already left with ItemDeleted.
+var itemEventTypes = map[string]string{
+	audit.ActionCreate:  events.ItemCreated,
+	audit.ActionUpdate:  events.ItemUpdated,
+	audit.ActionRestore: events.ItemUpdated,
+	audit.ActionDelete:  events.ItemDeleted,
+}
+
+// ClaimOutboxEvents leases up to limit due outbox events, oldest first, and counts
+// the attempt. Claimed events are not due again until lease has passed, so an event
+// whose publisher crashed is retried and other relays skip it meanwhile.
+func (r *repository) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	now := time.Now()
+	var claimed []models.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Raw(`UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = ?
+			WHERE id IN (
+				SELECT id FROM outbox_events WHERE next_attempt_at <= ?
+				ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED
+			) RETURNING *`, now.Add(lease), now, limit).
+		Scan(&claimed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	// RETURNING does not keep the subquery order
+	slices.SortFunc(claimed, func(a, b models.OutboxEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return claimed, nil
+}
+
+// DeleteOutboxEvent removes a published event from the outbox
+func (r *repository) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Delete(&models.OutboxEvent{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete outbox event: %w", err)
+	}
+	return nil
+}
+
+// RescheduleOutboxEvent records a failed publish and makes the event due again at next
+func (r *repository) RescheduleOutboxEvent(ctx context.Context, id int64, next time.Time, lastError string) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.OutboxEvent{ID: id}).
+		Updates(map[string]interface{}{
+			"next_attempt_at": next,
+			"last_error":      lastError,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+	return nil
+}
+
+// enqueueItemEvents adds the domain events of item changes to the outbox in tx
+// and announces them to stream listeners once tx commits
+func enqueueItemEvents(tx *gorm.DB, action string, changes []itemChange) error {
+	eventType, ok := itemEventTypes[action]
+	if !ok || len(changes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	outbox := make([]models.OutboxEvent, len(changes))
+	for i, change := range changes {
+		payload, err := json.Marshal(change.after)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+		}
+		outbox[i] = models.OutboxEvent{
+			EventType:     eventType,
+			AggregateType: AuditEntityItem,
+			AggregateID:   change.id,
+			Payload:       payload,
+			NextAttemptAt: now,
+		}
+	}
+
+	if err := tx.Omit("ID").CreateInBatches(outbox, createBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to enqueue outbox events: %w", err)
+	}
+	return notifyItemEvents(tx, outbox, changes)
+}
+
+// notifyItemEvents sends a NOTIFY per outbox event, delivered by Postgres on commit.
+// Items too large for a notification are announced with only their id and version.
+func notifyItemEvents(tx *gorm.DB, outbox []models.OutboxEvent, changes []itemChange) error {
+	payloads := make([]string, len(outbox))
+	for i := range outbox {
+		event := stream.Event{ID: outbox[i].ID, Type: outbox[i].EventType, Data: outbox[i].Payload}
+		payload, err := json.Marshal(event)
+		if err == nil && len(payload) > stream.MaxNotificationBytes {
+			event.Data, err = json.Marshal(map[string]int64{"id": changes[i].id, "version": changes[i].after.Version})
+			if err == nil {
+				payload, err = json.Marshal(event)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode item notification: %w", err)
+		}
+		payloads[i] = string(payload)
+	}
+
+	// One statement for the whole batch; a JSON array avoids GORM expanding a slice argument
+	batch, err := json.Marshal(payloads)
+	if err != nil {
+		return fmt.Errorf("failed to encode item notifications: %w", err)
+	}
+	err = tx.Exec("SELECT pg_notify(?, t.payload) FROM jsonb_array_elements_text(?::jsonb) AS t(payload)",
+		stream.Channel, string(batch)).Error
+	if err != nil {
+		return fmt.Errorf("failed to notify item events: %w", err)
+	}
+	return nil
+}
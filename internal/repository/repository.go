@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"time"
@@ -17,11 +18,62 @@ import (
 // Repository defines the interface for data access
 type Repository interface {
 	// Item operations
-	GetAllItems(ctx context.Context) ([]models.Item, error)
+	ListItems(ctx context.Context, opts ItemListOptions) (*models.ItemPage, error)
+	SearchItems(ctx context.Context, opts ItemSearchOptions) (*models.ItemSearchPage, error)
 	GetItemByID(ctx context.Context, id int64) (*models.Item, error)
 	CreateItem(ctx context.Context, item *models.Item) error
-	UpdateItem(ctx context.Context, item *models.Item) error
-	DeleteItem(ctx context.Context, id int64) error
+	UpdateItem(ctx context.Context, item *models.Item, ifVersions []int64) error
+	PatchItem(ctx context.Context, item *models.Item, columns []string, ifVersions []int64) error
+	DeleteItem(ctx context.Context, id int64, ifVersions []int64) error
+
+	// Bulk item operations
+	CreateItems(ctx context.Context, items []*models.Item) error
+	DeleteItems(ctx context.Context, deletes []ItemDelete) ([]error, error)
+	Transaction(ctx context.Context, fn func(tx Repository) error) error
+	StreamItems(ctx context.Context, fn func(item *models.Item) error) error
+
+	// Trash operations
+	ListDeletedItems(ctx context.Context, limit, offset int) (*models.ItemPage, error)
+	RestoreItem(ctx context.Context, id int64) (*models.Item, error)
+	PurgeItem(ctx context.Context, id int64) error
+	PurgeDeletedItems(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// Audit operations
+	ListAuditEvents(ctx context.Context, opts AuditListOptions) (*models.AuditPage, error)
+
+	// Webhook operations
+	CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) error
+	ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, sub *models.WebhookSubscription) error
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status string, limit, offset int) (*models.WebhookDeliveryPage, error)
+	GetWebhookDelivery(ctx context.Context, subscriptionID, id int64) (*models.WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, subscriptionID, id int64) (*models.WebhookDelivery, error)
+	EnqueueWebhookDeliveries(ctx context.Context, eventID int64, eventType string, payload json.RawMessage) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+
+	// Outbox operations
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	DeleteOutboxEvent(ctx context.Context, id int64) error
+	RescheduleOutboxEvent(ctx context.Context, id int64, next time.Time, lastError string) error
+
+	// Auth operations
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	GetUserByID(ctx context.Context, id int64) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) error
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, current, next *models.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+
+	// API key operations
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id int64, usedAt time.Time) error
+	RevokeAPIKey(ctx context.Context, id int64) error
 }
 
 type repository struct {
@@ -56,10 +108,10 @@ func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetConnMaxLifetime(time.Hour)
-	sqlDB.SetConnMaxIdleTime(10 * time.Minute)
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
 
 	slog.Info("Database connection established")
 
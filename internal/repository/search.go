@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// searchConfig is the Postgres text search configuration of queries. It must
+// match the configuration the search_vector column is generated with (migration
+// 0004), or query terms are stemmed differently from the stored vectors.
+const searchConfig = "english"
+
+// headlineOptions configure ts_headline: whole names are highlighted,
+// descriptions are shortened to the fragments around matches
+const (
+	nameHeadlineOptions        = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+)
+
+// escapeHTML returns a SQL expression HTML-escaping a text column, so the
+// only markup in highlights is the <mark> tags added by ts_headline
+func escapeHTML(column string) string {
+	return `replace(replace(replace(replace(replace(` + column +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// ItemSearchOptions controls SearchItems
+type ItemSearchOptions struct {
+	Query  string // websearch syntax: words, "quoted phrases", or, -excluded
+	Limit  int
+	Offset int
+}
+
+// SearchItems retrieves items matching a full-text query, ranked by relevance.
+// Highlights are HTML-escaped item text with matches wrapped in <mark></mark>.
+func (r *repository) SearchItems(ctx context.Context, opts ItemSearchOptions) (*models.ItemSearchPage, error) {
+	if opts.Limit <= 0 || opts.Limit > MaxListLimit {
+		opts.Limit = DefaultListLimit
+	}
+
+	page := &models.ItemSearchPage{Items: []models.ItemSearchResult{}}
+	err := r.db.WithContext(ctx).
+		Table("items, websearch_to_tsquery(?::regconfig, ?) AS query", searchConfig, opts.Query).
+		Select(
+			"items.id, items.name, items.description, items.version, items.created_at, items.updated_at, "+
+				"ts_rank(items.search_vector, query) AS rank, "+
+				"ts_headline(?::regconfig, "+escapeHTML("items.name")+", query, ?) AS name_highlight, "+
+				"ts_headline(?::regconfig, "+escapeHTML("items.description")+", query, ?) AS description_highlight",
+			searchConfig, nameHeadlineOptions, searchConfig, descriptionHeadlineOptions,
+		).
+		Where("items.search_vector @@ query AND items.deleted_at IS NULL").
+		Order("rank DESC, items.id DESC").
+		Limit(opts.Limit).
+		Offset(opts.Offset).
+		Scan(&page.Items).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// =============================================================================
+// SearchItems Tests
+// =============================================================================
+
+func TestSearchItems_EscapesHighlightedText(t *testing.T) {
+	db, _ := dryRunDB(t)
+	var statement string
+	capture := func(tx *gorm.DB) {
+		statement = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("test:capture", capture); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// Scanning is unsupported in dry run mode, after the statement is built
+	_, err := New(db).SearchItems(context.Background(), ItemSearchOptions{Query: "widget"})
+	if err != nil && !errors.Is(err, gorm.ErrDryRunModeUnsupported) {
+		t.Fatalf("SearchItems() error = %v", err)
+	}
+
+	for _, column := range []string{"items.name", "items.description"} {
+		want := "ts_headline('english'::regconfig, " + escapeHTML(column) + ", query,"
+		if !strings.Contains(statement, want) {
+			t.Errorf("statement = %s, want highlights of escaped %s", statement, column)
+		}
+	}
+	if !strings.Contains(statement, "websearch_to_tsquery('english'::regconfig, 'widget')") {
+		t.Errorf("statement = %s, want the query parsed with the english configuration", statement)
+	}
+}
+
+func TestEscapeHTML_EscapesMarkupCharacters(t *testing.T) {
+	got := escapeHTML("items.name")
+
+	for _, want := range []string{"'&', '&amp;'", "'<', '&lt;'", "'>', '&gt;'", `'"', '&quot;'`, "'''', '&#39;'"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("escapeHTML() = %s, want replacement %s", got, want)
+		}
+	}
+	// & is escaped first so the other entities are not escaped again
+	if !strings.HasPrefix(got, "replace(replace(replace(replace(replace(items.name, '&', '&amp;')") {
+		t.Errorf("escapeHTML() = %s, want & replaced first", got)
+	}
+}
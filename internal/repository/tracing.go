@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Span handling of QueryTracing
+const (
+	tracerName   = "github.com/GunarsK-templates/template-api/internal/repository"
+	querySpanKey = "tracing:span" // Stores the span of a statement in its GORM instance
+)
+
+// QueryTracing is a GORM plugin recording a client span for every statement, as a
+// child of the span in the statement's context (see db.WithContext). Spans carry the
+// operation, table and SQL with placeholders; argument values are never recorded.
+type QueryTracing struct {
+	tracer trace.Tracer
+}
+
+// NewQueryTracing returns the plugin creating spans with provider. Add it to a
+// connection with db.Use.
+func NewQueryTracing(provider trace.TracerProvider) *QueryTracing {
+	return &QueryTracing{tracer: provider.Tracer(tracerName)}
+}
+
+// Name implements gorm.Plugin
+func (t *QueryTracing) Name() string {
+	return "tracing"
+}
+
+// Initialize implements gorm.Plugin by wrapping each processor's main callback in a span
+func (t *QueryTracing) Initialize(db *gorm.DB) error {
+	return registerAround(db, t.Name(), t.before, t.after)
+}
+
+// before returns a callback starting the span of an operation
+func (t *QueryTracing) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		name := strings.TrimSpace(operation + " " + db.Statement.Table)
+		_, span := t.tracer.Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemNamePostgreSQL,
+				semconv.DBOperationName(operation),
+			),
+		)
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+// after returns a callback ending the span of an operation with its outcome
+func (t *QueryTracing) after(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, _ := db.InstanceGet(querySpanKey)
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		if db.Statement.Table != "" {
+			span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+		}
+		span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
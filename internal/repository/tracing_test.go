@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// tracedDB returns a dry-run connection recording query spans in an in-memory
+// exporter, and the provider for starting parent spans
+func tracedDB(t *testing.T) (*gorm.DB, *sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	db, _ := dryRunDB(t)
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	if err := db.Use(NewQueryTracing(provider)); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	return db, provider, exporter
+}
+
+// =============================================================================
+// QueryTracing Tests
+// =============================================================================
+
+func TestQueryTracing_RecordsSpanUnderRequest(t *testing.T) {
+	db, provider, exporter := tracedDB(t)
+	ctx, request := provider.Tracer("test").Start(context.Background(), "GET /items/:id")
+
+	db.WithContext(ctx).First(&models.Item{}, 7)
+	request.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want query and request", len(spans))
+	}
+	query := spans[0]
+	if query.Name != "query items" || query.SpanKind != trace.SpanKindClient {
+		t.Errorf("span = %q (%v), want client span \"query items\"", query.Name, query.SpanKind)
+	}
+	if query.Parent.SpanID() != request.SpanContext().SpanID() {
+		t.Error("query span should be a child of the request span")
+	}
+
+	attrs := make(map[string]string)
+	for _, kv := range query.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	want := map[string]string{
+		"db.system.name":     "postgresql",
+		"db.operation.name":  "query",
+		"db.collection.name": "items",
+		"db.query.text":      `SELECT * FROM "items" WHERE "items"."id" = $1 AND "items"."deleted_at" IS NULL ORDER BY "items"."id" LIMIT $2`,
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Errorf("%s = %q, want %q", key, attrs[key], value)
+		}
+	}
+}
+
+func TestQueryTracing_RecordsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "failed query", err: errors.New("connection reset"), want: codes.Error},
+		{name: "missing record", err: gorm.ErrRecordNotFound, want: codes.Unset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _, exporter := tracedDB(t)
+			failQueries(t, db, tt.err)
+
+			db.First(&models.Item{}, 1)
+
+			if got := exporter.GetSpans()[0].Status.Code; got != tt.want {
+				t.Errorf("span status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// CreateWebhook stores a new webhook subscription
+func (r *repository) CreateWebhook(ctx context.Context, sub *models.WebhookSubscription) error {
+	err := r.db.WithContext(ctx).
+		Omit("ID", "CreatedAt", "UpdatedAt").
+		Create(sub).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// ListWebhooks retrieves all webhook subscriptions, newest first
+func (r *repository) ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subs := []models.WebhookSubscription{}
+	err := r.db.WithContext(ctx).
+		Order("id DESC").
+		Find(&subs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return subs, nil
+}
+
+// GetWebhook retrieves a webhook subscription by ID
+func (r *repository) GetWebhook(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&sub, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &sub, nil
+}
+
+// UpdateWebhook writes the URL, event types, secret and active flag of a subscription
+func (r *repository) UpdateWebhook(ctx context.Context, sub *models.WebhookSubscription) error {
+	result := r.db.WithContext(ctx).
+		Model(sub).
+		Select("URL", "EventTypes", "Secret", "Active", "UpdatedAt").
+		Updates(sub)
+	if err := checkRowsAffected(result); err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes a webhook subscription with its deliveries and their history
+func (r *repository) DeleteWebhook(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&models.WebhookSubscription{}, id)
+	if err := checkRowsAffected(result); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries retrieves a page of a subscription's deliveries, newest first.
+// An empty status lists all of them.
+func (r *repository) ListWebhookDeliveries(ctx context.Context, subscriptionID int64, status string, limit, offset int) (*models.WebhookDeliveryPage, error) {
+	if limit <= 0 || limit > MaxListLimit {
+		limit = DefaultListLimit
+	}
+
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	page := &models.WebhookDeliveryPage{Deliveries: []models.WebhookDelivery{}}
+	err := query.
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&page.Deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return page, nil
+}
+
+// GetWebhookDelivery retrieves a delivery of a subscription with its attempts, newest first
+func (r *repository) GetWebhookDelivery(ctx context.Context, subscriptionID, id int64) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Preload("History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id DESC")
+		}).
+		Where("subscription_id = ?", subscriptionID).
+		First(&delivery, id).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// RedeliverWebhookDelivery makes a delivery of a subscription pending and due now
+// with a fresh set of attempts, whatever its status. Its history is kept.
+func (r *repository) RedeliverWebhookDelivery(ctx context.Context, subscriptionID, id int64) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{ID: id}
+	result := r.db.WithContext(ctx).
+		Model(delivery).
+		Clauses(clause.Returning{}).
+		Where("subscription_id = ?", subscriptionID).
+		Updates(map[string]interface{}{
+			"status":          models.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if err := checkRowsAffected(result); err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// EnqueueWebhookDeliveries queues a delivery of an event for every active subscription
+// to its type and returns how many were queued. Subscriptions that already have the
+// event are skipped, so enqueuing a republished event again is harmless.
+func (r *repository) EnqueueWebhookDeliveries(ctx context.Context, eventID int64, eventType string, payload json.RawMessage) (int64, error) {
+	eventTypes, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event type: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Exec(`INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, ?::bigint, ?::varchar, ?::jsonb FROM webhook_subscriptions
+		WHERE active AND event_types @> ?::jsonb
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+		eventID, eventType, string(payload), string(eventTypes))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ClaimWebhookDeliveries leases up to limit due pending deliveries of active subscriptions,
+// oldest first, with their subscription loaded, and counts the attempt. Claimed deliveries
+// are not due again until lease has passed, so other dispatchers skip them meanwhile.
+func (r *repository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	now := time.Now()
+	var ids []int64
+	err := r.db.WithContext(ctx).
+		Raw(`UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+			WHERE id IN (
+				SELECT d.id FROM webhook_deliveries d
+				JOIN webhook_subscriptions s ON s.id = d.subscription_id
+				WHERE d.status = ? AND d.next_attempt_at <= ? AND s.active
+				ORDER BY d.id LIMIT ? FOR UPDATE OF d SKIP LOCKED
+			) RETURNING id`, now.Add(lease), now, models.WebhookDeliveryPending, now, limit).
+		Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var claimed []models.WebhookDelivery
+	err = r.db.WithContext(ctx).
+		Preload("Subscription").
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&claimed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claimed webhook deliveries: %w", err)
+	}
+	return claimed, nil
+}
+
+// RecordWebhookAttempt stores an attempt and the resulting status, next attempt time,
+// last error, response status and delivered time of its delivery
+func (r *repository) RecordWebhookAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("ID").Create(attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(delivery).
+			Select("Status", "NextAttemptAt", "LastError", "ResponseStatus", "DeliveredAt", "UpdatedAt").
+			Updates(delivery).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+	return nil
+}
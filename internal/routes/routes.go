@@ -1,26 +1,48 @@
 package routes
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"github.com/GunarsK-templates/template-api/internal/audit"
 	"github.com/GunarsK-templates/template-api/internal/config"
 	"github.com/GunarsK-templates/template-api/internal/handlers"
+	"github.com/GunarsK-templates/template-api/internal/i18n"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+	"github.com/GunarsK-templates/template-api/internal/problem"
 	// Uncomment after running: swag init -g cmd/api/main.go -o docs
 	// _ "github.com/GunarsK-templates/template-api/docs"
 )
 
-// Setup configures all routes for the service
-func Setup(router *gin.Engine, handler *handlers.Handler, cfg *config.Config) {
+// Setup configures all routes for the service.
+// verifier validates bearer tokens and is nil when JWT is not configured.
+// apiKeys looks up X-API-Key credentials accepted alongside bearer tokens.
+func Setup(router *gin.Engine, handler *handlers.Handler, cfg *config.Config, verifier *auth.Verifier, apiKeys auth.APIKeyStore) {
 	// CORS middleware
 	router.Use(corsMiddleware(cfg.Service.AllowedOrigins))
 
 	// Security headers
 	router.Use(securityHeaders())
 
-	// Health check (unprotected)
+	// Unknown routes answer with problem details like every other error
+	router.NoRoute(func(c *gin.Context) {
+		problem.Respond(c, problem.New(problem.CodeNotFound, i18n.T(c.Request.Context(), "Route not found")))
+	})
+
+	// Health probes (unprotected)
 	router.GET("/health", handler.HealthCheck)
+	router.GET("/health/live", handler.Liveness)
+	router.GET("/health/ready", handler.Readiness)
+	router.GET("/health/startup", handler.Startup)
+
+	// Public signing keys (only when asymmetric keys are configured)
+	if cfg.HasJWT() && len(cfg.JWT.VerificationKeys) > 0 {
+		router.GET("/.well-known/jwks.json", handler.JWKS)
+	}
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -29,25 +51,86 @@ func Setup(router *gin.Engine, handler *handlers.Handler, cfg *config.Config) {
 		items := v1.Group("/items")
 		{
 			items.GET("", handler.GetItems)
+			items.GET("/search", handler.SearchItems)
+			items.GET("/events", handler.StreamItemEvents)
 			items.GET("/:id", handler.GetItem)
 		}
 
-		// Protected routes (require auth)
-		// Uncomment and add auth middleware when JWT is configured
-		// if cfg.HasJWT() {
-		//     protected := v1.Group("")
-		//     protected.Use(authMiddleware(cfg.JWT.Secret))
-		//     {
-		//         protected.POST("/items", handler.CreateItem)
-		//         protected.PUT("/items/:id", handler.UpdateItem)
-		//         protected.DELETE("/items/:id", handler.DeleteItem)
-		//     }
-		// }
-
-		// For now, all routes are public (remove in production)
-		items.POST("", handler.CreateItem)
-		items.PUT("/:id", handler.UpdateItem)
-		items.DELETE("/:id", handler.DeleteItem)
+		// Write routes require a valid bearer token or an API key with the items:write
+		// scope when JWT is configured; deleting, the trash and export/import additionally
+		// require the admin role. Without JWT they stay public (development only).
+		itemWrites := v1.Group("/items")
+		if verifier != nil {
+			itemWrites.Use(
+				auth.APIKeyMiddleware(apiKeys),
+				auth.Middleware(verifier),
+				auth.RequireAPIKeyScopes(auth.ScopeItemsWrite),
+			)
+		}
+		itemWrites.Use(auditMetadata())
+		// Sub-groups copy middleware at creation, so create after Use
+		itemAdmin := itemWrites.Group("")
+		if verifier != nil {
+			itemAdmin.Use(auth.RequireRoles(auth.RoleAdmin))
+		}
+		{
+			itemWrites.POST("", handler.CreateItem)
+			itemWrites.POST("/bulk", handler.BulkItems)
+			itemWrites.PUT("/:id", handler.UpdateItem)
+			itemWrites.PATCH("/:id", handler.PatchItem)
+			itemAdmin.DELETE("/:id", handler.DeleteItem)
+			itemAdmin.GET("/export", handler.ExportItems)
+			itemAdmin.POST("/import", handler.ImportItems)
+			itemAdmin.GET("/trash", handler.ListTrash)
+			itemAdmin.POST("/:id/restore", handler.RestoreItem)
+			itemAdmin.DELETE("/trash/:id", handler.PurgeItem)
+		}
+
+		// Audit log and item history (admin only, so only when JWT is configured:
+		// they expose actors, request IDs and the diffs of every write)
+		if verifier != nil {
+			auditAdmin := v1.Group("", auth.Middleware(verifier), auth.RequireRoles(auth.RoleAdmin))
+			{
+				auditAdmin.GET("/audit", handler.ListAuditEvents)
+				auditAdmin.GET("/items/:id/history", handler.ItemHistory)
+			}
+		}
+
+		// Webhook subscriptions (admin only, so only when JWT is configured: they make
+		// the service send requests to caller-chosen URLs)
+		if verifier != nil {
+			webhookAdmin := v1.Group("/webhooks", auth.Middleware(verifier), auth.RequireRoles(auth.RoleAdmin))
+			{
+				webhookAdmin.POST("", handler.CreateWebhook)
+				webhookAdmin.GET("", handler.ListWebhooks)
+				webhookAdmin.GET("/:id", handler.GetWebhook)
+				webhookAdmin.PUT("/:id", handler.UpdateWebhook)
+				webhookAdmin.DELETE("/:id", handler.DeleteWebhook)
+				webhookAdmin.GET("/:id/deliveries", handler.ListWebhookDeliveries)
+				webhookAdmin.GET("/:id/deliveries/:deliveryId", handler.GetWebhookDelivery)
+				webhookAdmin.POST("/:id/deliveries/:deliveryId/redeliver", handler.RedeliverWebhook)
+			}
+		}
+
+		// Token endpoints (only when tokens can be signed)
+		if cfg.JWT.CanSign() {
+			authGroup := v1.Group("/auth")
+			{
+				authGroup.POST("/token", handler.IssueToken)
+				authGroup.POST("/refresh", handler.RefreshToken)
+				authGroup.POST("/logout", handler.Logout)
+			}
+		}
+
+		// API key management (admin bearer tokens only, so only when JWT is configured)
+		if verifier != nil {
+			apiKeyAdmin := v1.Group("/api-keys", auth.Middleware(verifier), auth.RequireRoles(auth.RoleAdmin))
+			{
+				apiKeyAdmin.POST("", handler.CreateAPIKey)
+				apiKeyAdmin.GET("", handler.ListAPIKeys)
+				apiKeyAdmin.DELETE("/:id", handler.RevokeAPIKey)
+			}
+		}
 	}
 
 	// Swagger documentation (only if host is configured)
@@ -73,8 +156,9 @@ func corsMiddleware(allowedOrigins []string) gin.HandlerFunc {
 		if allowed {
 			c.Header("Access-Control-Allow-Origin", origin)
 			c.Header("Access-Control-Allow-Credentials", "true")
-			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
-			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, X-Requested-With, X-Request-ID, If-Match, If-None-Match, Last-Event-ID, traceparent, tracestate")
+			c.Header("Access-Control-Expose-Headers", "ETag, X-Request-ID")
+			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 			c.Header("Access-Control-Max-Age", "86400")
 		}
 
@@ -98,3 +182,27 @@ func securityHeaders() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// auditMetadata stores the authenticated caller and the request ID in the
+// request context, where repository writes pick them up for audit events.
+// Must run after the auth middleware; without claims the actor is anonymous.
+func auditMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var meta audit.Metadata
+		if claims, ok := auth.GetClaims(c); ok {
+			meta.Actor = truncate(claims.Subject, 255)
+		}
+		meta.RequestID = requestid.FromContext(c.Request.Context())
+
+		c.Request = c.Request.WithContext(audit.WithMetadata(c.Request.Context(), meta))
+		c.Next()
+	}
+}
+
+// truncate shortens s to at most n bytes of valid UTF-8 to fit its database column
+func truncate(s string, n int) string {
+	if len(s) > n {
+		s = s[:n]
+	}
+	return strings.ToValidUTF8(s, "")
+}
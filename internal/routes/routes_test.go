@@ -0,0 +1,150 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+	"github.com/GunarsK-templates/template-api/internal/handlers"
+	"github.com/GunarsK-templates/template-api/internal/middleware/auth"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// apiKeyStore is an in-memory auth.APIKeyStore keyed by hash
+type apiKeyStore map[string]*models.APIKey
+
+func (s apiKeyStore) GetAPIKeyByHash(_ context.Context, hash string) (*models.APIKey, error) {
+	if key, ok := s[hash]; ok {
+		return key, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s apiKeyStore) TouchAPIKey(context.Context, int64, time.Time) error {
+	return nil
+}
+
+// addKey generates an API key with the given scopes and returns it in plaintext
+func (s apiKeyStore) addKey(t *testing.T, scopes string) string {
+	t.Helper()
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	s[hash] = &models.APIKey{ID: int64(len(s) + 1), Prefix: prefix, KeyHash: hash, Scopes: scopes}
+	return key
+}
+
+// newTestRouter sets up all routes with JWT configured and the given API keys
+func newTestRouter(t *testing.T, keys apiKeyStore) (*gin.Engine, *config.Config) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{JWT: &config.JWTConfig{
+		Secret:        "test-secret-that-is-at-least-32-bytes",
+		AccessExpiry:  15 * time.Minute,
+		RefreshExpiry: time.Hour,
+		RolesClaim:    "roles",
+		ScopesClaim:   "scope",
+	}}
+	verifier, err := auth.NewVerifier(context.Background(), cfg.JWT)
+	if err != nil {
+		t.Fatalf("NewVerifier() error = %v", err)
+	}
+
+	router := gin.New()
+	Setup(router, handlers.New(nil, cfg, nil, nil), cfg, verifier, keys)
+	return router, cfg
+}
+
+// =============================================================================
+// Item Write Authorization Tests
+// =============================================================================
+
+func TestSetup_ItemWritesRequireAPIKeyScope(t *testing.T) {
+	keys := apiKeyStore{}
+	router, cfg := newTestRouter(t, keys)
+	bearer, _, err := auth.NewTokenIssuer(cfg.JWT).IssueAccessToken("42", nil)
+	if err != nil {
+		t.Fatalf("IssueAccessToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		wantCode int
+	}{
+		{name: "api key without scopes", header: auth.APIKeyHeader, value: keys.addKey(t, ""), wantCode: http.StatusForbidden},
+		{name: "read-only api key", header: auth.APIKeyHeader, value: keys.addKey(t, "items:read"), wantCode: http.StatusForbidden},
+		// Authorized requests reach the handler, which rejects the empty body
+		{name: "api key with items:write", header: auth.APIKeyHeader, value: keys.addKey(t, "items:read items:write"), wantCode: http.StatusBadRequest},
+		{name: "bearer token", header: "Authorization", value: "Bearer " + bearer, wantCode: http.StatusBadRequest},
+		{name: "no credentials", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range []string{"/api/v1/items", "/api/v1/items/bulk"} {
+				req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{}`))
+				req.Header.Set("Content-Type", "application/json")
+				if tt.header != "" {
+					req.Header.Set(tt.header, tt.value)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != tt.wantCode {
+					t.Errorf("POST %s status = %d, want %d (body %s)", target, w.Code, tt.wantCode, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// =============================================================================
+// JWT-only Route Tests
+// =============================================================================
+
+func TestSetup_AdminDataRoutesRequireJWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{}
+	router := gin.New()
+	Setup(router, handlers.New(nil, cfg, nil, nil), cfg, nil, apiKeyStore{})
+
+	for _, route := range []struct{ method, target string }{
+		{http.MethodPost, "/api/v1/webhooks"},
+		{http.MethodGet, "/api/v1/webhooks"},
+		{http.MethodPut, "/api/v1/webhooks/1"},
+		{http.MethodPost, "/api/v1/webhooks/1/deliveries/2/redeliver"},
+		{http.MethodGet, "/api/v1/audit"},
+		{http.MethodGet, "/api/v1/items/1/history"},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(route.method, route.target, strings.NewReader(`{}`)))
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s %s without JWT status = %d, want 404", route.method, route.target, w.Code)
+		}
+	}
+
+	router, _ = newTestRouter(t, apiKeyStore{})
+	for _, target := range []string{"/api/v1/webhooks", "/api/v1/audit", "/api/v1/items/1/history"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s with JWT configured status = %d, want 401", target, w.Code)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package stream
+
+import "sync"
+
+// subscriberBuffer is how many events a client may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// Broker keeps the latest events in a bounded buffer and delivers new ones to subscribers
+type Broker struct {
+	mu     sync.Mutex
+	events []Event // Oldest first, at most size
+	size   int
+	subs   map[*Subscription]struct{}
+}
+
+// Subscription receives events published after it was created
+type Subscription struct {
+	broker *Broker
+	ch     chan Event
+}
+
+// NewBroker returns a broker that keeps the latest size events for resuming clients
+func NewBroker(size int) *Broker {
+	return &Broker{size: size, subs: make(map[*Subscription]struct{})}
+}
+
+// Publish buffers an event and sends it to all subscribers. Subscribers that have
+// fallen subscriberBuffer events behind are dropped; they can resume from the buffer.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.events) == b.size {
+		copy(b.events, b.events[1:])
+		b.events = b.events[:b.size-1]
+	}
+	b.events = append(b.events, event)
+
+	for sub := range b.subs {
+		select {
+		case sub.ch <- event:
+		default:
+			b.drop(sub)
+		}
+	}
+}
+
+// Reset forgets buffered events and drops all subscribers, for when events may have
+// been missed. Reconnecting clients cannot resume and are told to reload instead.
+func (b *Broker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events = nil
+	for sub := range b.subs {
+		b.drop(sub)
+	}
+}
+
+// Subscribe starts a subscription. With resume set, it also returns the buffered events
+// after the one with ID lastEventID; ok is false if that event is no longer buffered,
+// so events in between may have been missed.
+func (b *Broker) Subscribe(lastEventID int64, resume bool) (sub *Subscription, replay []Event, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub = &Subscription{broker: b, ch: make(chan Event, subscriberBuffer)}
+	b.subs[sub] = struct{}{}
+	if !resume {
+		return sub, nil, true
+	}
+
+	// Events are buffered in arrival order, which is commit order on every replica
+	for i := range b.events {
+		if b.events[i].ID == lastEventID {
+			return sub, append([]Event(nil), b.events[i+1:]...), true
+		}
+	}
+	return sub, nil, false
+}
+
+// Events returns the channel of new events; it is closed when the subscriber is dropped
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
+
+// drop removes a subscriber and closes its channel; b.mu must be held
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+func testEvent(id int64) Event {
+	return Event{ID: id, Type: "ItemUpdated", Data: json.RawMessage(`{}`)}
+}
+
+// eventIDs returns the IDs of events
+func eventIDs(events []Event) []int64 {
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// =============================================================================
+// Broker Tests
+// =============================================================================
+
+func TestBroker_DeliversNewEvents(t *testing.T) {
+	b := NewBroker(10)
+	b.Publish(testEvent(1))
+
+	sub, replay, ok := b.Subscribe(0, false)
+	defer sub.Close()
+	b.Publish(testEvent(2))
+
+	if !ok || len(replay) != 0 {
+		t.Errorf("Subscribe() replay = %v, ok = %v, want nothing without resume", eventIDs(replay), ok)
+	}
+	if got := <-sub.Events(); got.ID != 2 {
+		t.Errorf("received event %d, want 2", got.ID)
+	}
+}
+
+func TestBroker_ResumesAfterLastEventID(t *testing.T) {
+	b := NewBroker(3)
+	for id := int64(1); id <= 5; id++ {
+		b.Publish(testEvent(id))
+	}
+
+	tests := []struct {
+		name       string
+		lastID     int64
+		wantReplay []int64
+		wantOK     bool
+	}{
+		{name: "in buffer", lastID: 3, wantReplay: []int64{4, 5}, wantOK: true},
+		{name: "latest", lastID: 5, wantReplay: []int64{}, wantOK: true},
+		{name: "evicted", lastID: 2, wantReplay: []int64{}, wantOK: false},
+		{name: "unknown", lastID: 99, wantReplay: []int64{}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay, ok := b.Subscribe(tt.lastID, true)
+			defer sub.Close()
+
+			got := eventIDs(replay)
+			if ok != tt.wantOK || len(got) != len(tt.wantReplay) {
+				t.Fatalf("Subscribe(%d) = %v, %v, want %v, %v", tt.lastID, got, ok, tt.wantReplay, tt.wantOK)
+			}
+			for i := range got {
+				if got[i] != tt.wantReplay[i] {
+					t.Errorf("Subscribe(%d) replay = %v, want %v", tt.lastID, got, tt.wantReplay)
+				}
+			}
+		})
+	}
+}
+
+func TestBroker_DropsSlowSubscribers(t *testing.T) {
+	b := NewBroker(1000)
+	slow, _, _ := b.Subscribe(0, false)
+
+	for id := int64(1); id <= subscriberBuffer+1; id++ {
+		b.Publish(testEvent(id))
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("received %d events before being dropped, want %d", received, subscriberBuffer)
+	}
+	slow.Close() // Closing a dropped subscription is harmless
+}
+
+func TestBroker_ResetDropsSubscribersAndBuffer(t *testing.T) {
+	b := NewBroker(10)
+	b.Publish(testEvent(1))
+	sub, _, _ := b.Subscribe(0, false)
+
+	b.Reset()
+
+	if _, open := <-sub.Events(); open {
+		t.Error("subscription should be closed by Reset")
+	}
+	resumed, _, ok := b.Subscribe(1, true)
+	defer resumed.Close()
+	if ok {
+		t.Error("events before a reset should not be resumable")
+	}
+}
@@ -0,0 +1,71 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Reconnect delays after the LISTEN connection fails
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Listen receives item notifications on a dedicated connection to dsn and publishes
+// them to broker until ctx is done. After a lost connection it reconnects with backoff
+// and resets the broker, because notifications sent meanwhile are gone.
+func Listen(ctx context.Context, dsn string, broker *Broker) {
+	delay := minReconnectDelay
+	for connected := false; ; {
+		err := listen(ctx, dsn, broker, func() {
+			if connected {
+				broker.Reset()
+			}
+			connected = true
+			delay = minReconnectDelay
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("Item event listener disconnected", "error", err.Error(), "retry_in", delay.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen runs one LISTEN connection, calling onListen once it is listening
+func listen(ctx context.Context, dsn string, broker *Broker, onListen func()) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background()) //nolint:errcheck // connection is discarded
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+	onListen()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			slog.Warn("Ignoring invalid item notification", "error", err.Error())
+			continue
+		}
+		broker.Publish(event)
+	}
+}
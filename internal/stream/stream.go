@@ -0,0 +1,20 @@
+// Package stream fans item change events out to Server-Sent Events clients.
+// Writes announce committed changes with Postgres NOTIFY on Channel; every replica
+// LISTENs and keeps the latest events in a Broker that clients can resume from.
+package stream
+
+import "encoding/json"
+
+// Channel is the NOTIFY channel item changes are announced on
+const Channel = "item_events"
+
+// MaxNotificationBytes keeps NOTIFY payloads under the Postgres limit of 8000 bytes.
+// Events whose item does not fit carry only its id and version.
+const MaxNotificationBytes = 7900
+
+// Event is one item change, also the JSON payload of its notification
+type Event struct {
+	ID   int64           `json:"id"`   // Outbox event ID; increases with every change
+	Type string          `json:"type"` // ItemCreated, ItemUpdated or ItemDeleted
+	Data json.RawMessage `json:"data"` // The item after the change
+}
@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+)
+
+// LogHandler adds the request ID and the trace and span IDs of a record's context
+// to the record, so logs written with slog.InfoContext and friends can be found
+// from a response or a trace
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps handler
+func NewLogHandler(handler slog.Handler) *LogHandler {
+	return &LogHandler{Handler: handler}
+}
+
+// Handle adds request_id when ctx belongs to a request, and trace_id and span_id
+// when it carries a span
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the wrapper around the derived handler
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewLogHandler(h.Handler.WithAttrs(attrs))
+}
+
+// WithGroup keeps the wrapper around the derived handler
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return NewLogHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,85 @@
+// Package telemetry sets up OpenTelemetry tracing and correlates log records
+// with traces.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/GunarsK-templates/template-api/internal/config"
+)
+
+// Setup installs the global W3C trace context propagator and, unless tracing is
+// disabled, a global tracer provider exporting to the configured exporter.
+// The returned shutdown flushes buffered spans.
+//
+// With tracing disabled the global provider stays a no-op, but trace IDs of
+// incoming requests are still propagated into their log records.
+func Setup(ctx context.Context, cfg config.TracingConfig, serviceName, environment string) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// OTEL_RESOURCE_ATTRIBUTES can add attributes such as the version
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.DeploymentEnvironmentName(environment),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := NewTracerProvider(cfg.SampleRatio, sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider returns a provider recording sampleRatio of new traces and
+// following the sampling decision of traces continued from a caller.
+// Tests pass an in-memory exporter with sdktrace.WithSyncer.
+func NewTracerProvider(sampleRatio float64, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))
+	return sdktrace.NewTracerProvider(append(opts, sdktrace.WithSampler(sampler))...)
+}
+
+// newExporter creates the configured span exporter
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return exporter, nil
+	case config.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+}
@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/GunarsK-templates/template-api/internal/middleware/requestid"
+)
+
+// =============================================================================
+// Test Helpers
+// =============================================================================
+
+// sampledParent returns a context continuing a remote trace with the given sampling decision
+func sampledParent(sampled bool) context.Context {
+	flags := trace.TraceFlags(0)
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: flags,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(context.Background(), parent)
+}
+
+// logJSON decodes the record that write logs through a LogHandler
+func logJSON(t *testing.T, write func(log *slog.Logger)) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	write(slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))))
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log record %s: %v", buf.String(), err)
+	}
+	return record
+}
+
+// =============================================================================
+// NewTracerProvider Tests
+// =============================================================================
+
+func TestNewTracerProvider_SamplesByRatioAndParent(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+		ctx   context.Context
+		want  int
+	}{
+		{name: "new trace, ratio 1", ratio: 1, ctx: context.Background(), want: 1},
+		{name: "new trace, ratio 0", ratio: 0, ctx: context.Background(), want: 0},
+		{name: "sampled caller, ratio 0", ratio: 0, ctx: sampledParent(true), want: 1},
+		{name: "unsampled caller, ratio 1", ratio: 1, ctx: sampledParent(false), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := NewTracerProvider(tt.ratio, sdktrace.WithSyncer(exporter))
+
+			_, span := provider.Tracer("test").Start(tt.ctx, "GET /items")
+			span.End()
+
+			if got := len(exporter.GetSpans()); got != tt.want {
+				t.Errorf("exported spans = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// LogHandler Tests
+// =============================================================================
+
+func TestLogHandler_AddsTraceAndSpanIDs(t *testing.T) {
+	provider := NewTracerProvider(1, sdktrace.WithSyncer(tracetest.NewInMemoryExporter()))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "GET /items")
+	defer span.End()
+
+	tests := []struct {
+		name  string
+		write func(log *slog.Logger)
+	}{
+		{name: "plain", write: func(log *slog.Logger) { log.InfoContext(ctx, "Request") }},
+		{name: "with attributes", write: func(log *slog.Logger) { log.With("component", "api").InfoContext(ctx, "Request") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := logJSON(t, tt.write)
+
+			if record["trace_id"] != span.SpanContext().TraceID().String() || record["span_id"] != span.SpanContext().SpanID().String() {
+				t.Errorf("record = %v, want trace_id and span_id of the span", record)
+			}
+		})
+	}
+}
+
+func TestLogHandler_AddsRequestID(t *testing.T) {
+	ctx := requestid.WithID(context.Background(), "req-1")
+
+	record := logJSON(t, func(log *slog.Logger) { log.ErrorContext(ctx, "Request error") })
+
+	if record["request_id"] != "req-1" {
+		t.Errorf("record = %v, want request_id req-1", record)
+	}
+}
+
+func TestLogHandler_SkipsContextsWithoutRequestOrSpan(t *testing.T) {
+	record := logJSON(t, func(log *slog.Logger) { log.Info("Request") })
+
+	for _, key := range []string{"request_id", "trace_id", "span_id"} {
+		if _, ok := record[key]; ok {
+			t.Errorf("record = %v, want no %s", record, key)
+		}
+	}
+}
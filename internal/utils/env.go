@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -82,3 +83,34 @@ func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return duration
 }
+
+// GetEnvFloat returns the value of an environment variable as a float or a default value
+func GetEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
+
+// GetEnvFloatSlice returns the value of an environment variable as comma-separated
+// floats or a default value if it is unset or any element is invalid
+func GetEnvFloatSlice(key string, defaultValue []float64) []float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var result []float64
+	for _, part := range strings.Split(value, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return defaultValue
+		}
+		result = append(result, f)
+	}
+	return result
+}
@@ -358,3 +358,80 @@ func TestGetEnvDuration_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+// =============================================================================
+// GetEnvFloat Tests
+// =============================================================================
+
+func TestGetEnvFloat_TableDriven(t *testing.T) {
+	tests := []struct {
+		name         string
+		envValue     string
+		setEnv       bool
+		defaultValue float64
+		want         float64
+	}{
+		{name: "returns default when not set", setEnv: false, defaultValue: 0.5, want: 0.5},
+		{name: "parses fraction", envValue: "0.25", setEnv: true, defaultValue: 1, want: 0.25},
+		{name: "parses integer", envValue: "1", setEnv: true, defaultValue: 0, want: 1},
+		{name: "returns default for invalid float", envValue: "half", setEnv: true, defaultValue: 0.5, want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := "TEST_FLOAT"
+			clearEnvForTest(t, key)
+
+			if tt.setEnv {
+				setEnvForTest(t, key, tt.envValue)
+			}
+
+			got := GetEnvFloat(key, tt.defaultValue)
+
+			if got != tt.want {
+				t.Errorf("GetEnvFloat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// GetEnvFloatSlice Tests
+// =============================================================================
+
+func TestGetEnvFloatSlice_TableDriven(t *testing.T) {
+	defaultValue := []float64{1}
+	tests := []struct {
+		name     string
+		envValue string
+		setEnv   bool
+		want     []float64
+	}{
+		{name: "returns default when not set", setEnv: false, want: defaultValue},
+		{name: "parses values with spaces", envValue: "0.1, 0.5,2", setEnv: true, want: []float64{0.1, 0.5, 2}},
+		{name: "returns default for invalid element", envValue: "0.1,abc", setEnv: true, want: defaultValue},
+		{name: "returns default for empty element", envValue: "0.1,,2", setEnv: true, want: defaultValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := "TEST_FLOAT_SLICE"
+			clearEnvForTest(t, key)
+
+			if tt.setEnv {
+				setEnvForTest(t, key, tt.envValue)
+			}
+
+			got := GetEnvFloatSlice(key, defaultValue)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetEnvFloatSlice() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetEnvFloatSlice() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package utils
+
+import "strings"
+
+// PathExcluded reports whether route is one of paths or below one of them,
+// e.g. "/health/live" for "/health"
+func PathExcluded(route string, paths []string) bool {
+	for _, path := range paths {
+		if route == path || strings.HasPrefix(route, strings.TrimSuffix(path, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
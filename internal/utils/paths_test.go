@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+// =============================================================================
+// PathExcluded Tests
+// =============================================================================
+
+func TestPathExcluded_TableDriven(t *testing.T) {
+	paths := []string{"/health", "/metrics/"}
+
+	tests := []struct {
+		route string
+		want  bool
+	}{
+		{route: "/health", want: true},
+		{route: "/health/live", want: true},
+		{route: "/metrics/", want: true},
+		{route: "/metrics/extra", want: true},
+		{route: "/healthz"},
+		{route: "/api/v1/items"},
+		{route: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			if got := PathExcluded(tt.route, paths); got != tt.want {
+				t.Errorf("PathExcluded(%q) = %v, want %v", tt.route, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenDestination is returned for deliveries to addresses that are not
+// publicly routable, which would let subscribers reach internal services
+var ErrForbiddenDestination = errors.New("webhook destination is not a public address")
+
+// NewClient returns the HTTP client deliveries are sent with. It only connects
+// to public addresses, checked on the resolved IP so DNS cannot bypass it, and
+// does not follow redirects: a 3xx response is a failed delivery.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would connect on our behalf, unchecked
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialControl rejects connections to addresses that are not public
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, address)
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, addrPort.Addr())
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublic reports whether addr is a publicly routable unicast address
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// =============================================================================
+// Client Tests
+// =============================================================================
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14", want: true},
+		{addr: "2606:4700::6810:84e5", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "0.0.0.0"},
+		{addr: "::"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "100.64.0.1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "224.0.0.1"},
+		{addr: "255.255.255.255"},
+		{addr: "::ffff:127.0.0.1"},
+		{addr: "::ffff:169.254.169.254"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_RefusesLoopbackDestination(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := &models.WebhookDelivery{
+		ID:           1,
+		EventType:    events.ItemCreated,
+		Payload:      []byte(`{}`),
+		Subscription: &models.WebhookSubscription{URL: server.URL, Secret: testSecret},
+	}
+	status, err := Send(context.Background(), NewClient(time.Second), delivery, time.Now())
+
+	if !errors.Is(err, ErrForbiddenDestination) || status != 0 {
+		t.Errorf("Send() = %d, %v, want ErrForbiddenDestination", status, err)
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want none", requests)
+	}
+}
+
+func TestNewClient_DoesNotFollowRedirects(t *testing.T) {
+	client := NewClient(time.Second)
+
+	if err := client.CheckRedirect(nil, nil); !errors.Is(err, http.ErrUseLastResponse) {
+		t.Errorf("CheckRedirect() = %v, want http.ErrUseLastResponse", err)
+	}
+}
@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Signature Tests
+// =============================================================================
+
+func TestSign_VerifiesRoundTrip(t *testing.T) {
+	body := []byte(`{"id":1,"type":"ItemCreated"}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	header := Sign(testSecret, now, body)
+
+	if !strings.HasPrefix(header, "t=1700000000,v1=") {
+		t.Errorf("Sign() = %q, want t=1700000000,v1=<hex>", header)
+	}
+	if err := Verify(testSecret, header, body, now.Add(time.Minute), 5*time.Minute); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestVerify_RejectsInvalidSignatures(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	now := time.Unix(1_700_000_000, 0)
+	header := Sign(testSecret, now, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "tampered body", secret: testSecret, header: header, body: `{"id":2}`, now: now, wantErr: ErrInvalidSignature},
+		{name: "wrong secret", secret: "whsec_other", header: header, body: `{"id":1}`, now: now, wantErr: ErrInvalidSignature},
+		{name: "missing mac", secret: testSecret, header: "t=1700000000", body: `{"id":1}`, now: now, wantErr: ErrInvalidSignature},
+		{name: "bad timestamp", secret: testSecret, header: "t=abc,v1=00", body: `{"id":1}`, now: now, wantErr: ErrInvalidSignature},
+		{name: "replayed later", secret: testSecret, header: header, body: `{"id":1}`, now: now.Add(time.Hour), wantErr: ErrStaleSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, []byte(tt.body), tt.now, 5*time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSecret_IsRandomAndPrefixed(t *testing.T) {
+	a, errA := NewSecret()
+	b, errB := NewSecret()
+	if errA != nil || errB != nil {
+		t.Fatalf("NewSecret() errors = %v, %v", errA, errB)
+	}
+	if !strings.HasPrefix(a, secretPrefix) || len(a) != len(secretPrefix)+48 || a == b {
+		t.Errorf("NewSecret() = %q, %q, want distinct whsec_ secrets", a, b)
+	}
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+// Delivery headers besides SignatureHeader
+const (
+	DeliveryHeader  = "X-Webhook-Delivery" // Delivery ID, stable across retries
+	EventTypeHeader = "X-Webhook-Event"    // e.g. ItemCreated
+)
+
+// Send POSTs a delivery to its subscription, signed at now, and returns the response
+// status (0 if none was received). Any 2xx status is a success; others are errors.
+func Send(ctx context.Context, client *http.Client, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	sub := delivery.Subscription
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, now, delivery.Payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()                                       //nolint:errcheck // response body is drained and discarded
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Allow connection reuse
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// DeliveryStore queues deliveries of an event to the subscriptions interested in it
+type DeliveryStore interface {
+	EnqueueWebhookDeliveries(ctx context.Context, eventID int64, eventType string, payload json.RawMessage) (int64, error)
+}
+
+// Fanout is an events.Publisher that queues a delivery of each event for every
+// active subscription of its type. Queuing is idempotent per event and subscription,
+// so republished events are not delivered twice.
+type Fanout struct {
+	store DeliveryStore
+}
+
+// NewFanout returns a publisher that queues deliveries in store
+func NewFanout(store DeliveryStore) *Fanout {
+	return &Fanout{store: store}
+}
+
+// Publish queues the event for its subscriptions
+func (f *Fanout) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if _, err := f.store.EnqueueWebhookDeliveries(ctx, event.ID, event.Type, payload); err != nil {
+		return err
+	}
+	return nil
+}
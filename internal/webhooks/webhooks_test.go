@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GunarsK-templates/template-api/internal/events"
+	"github.com/GunarsK-templates/template-api/internal/models"
+)
+
+const testSecret = "whsec_test-secret-value"
+
+// =============================================================================
+// Send Tests
+// =============================================================================
+
+func TestSend_PostsSignedPayload(t *testing.T) {
+	payload := json.RawMessage(`{"id":7,"type":"ItemUpdated"}`)
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := &models.WebhookDelivery{
+		ID:           12,
+		EventType:    events.ItemUpdated,
+		Payload:      payload,
+		Subscription: &models.WebhookSubscription{URL: server.URL, Secret: testSecret},
+	}
+	now := time.Now()
+
+	status, err := Send(context.Background(), server.Client(), delivery, now)
+
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("Send() = %d, %v, want 204", status, err)
+	}
+	if string(body) != string(payload) {
+		t.Errorf("body = %s, want %s", body, payload)
+	}
+	if received.Header.Get(DeliveryHeader) != "12" || received.Header.Get(EventTypeHeader) != events.ItemUpdated {
+		t.Errorf("headers = %v", received.Header)
+	}
+	if err := Verify(testSecret, received.Header.Get(SignatureHeader), body, now, time.Minute); err != nil {
+		t.Errorf("receiver could not verify signature: %v", err)
+	}
+}
+
+func TestSend_ReturnsStatusOfFailedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	delivery := &models.WebhookDelivery{
+		Payload:      json.RawMessage(`{}`),
+		Subscription: &models.WebhookSubscription{URL: server.URL, Secret: testSecret},
+	}
+
+	status, err := Send(context.Background(), server.Client(), delivery, time.Now())
+	if status != http.StatusGone || err == nil {
+		t.Errorf("Send() = %d, %v, want 410 and an error", status, err)
+	}
+}
+
+// =============================================================================
+// Fanout Tests
+// =============================================================================
+
+// fakeDeliveryStore records enqueued events
+type fakeDeliveryStore struct {
+	eventID   int64
+	eventType string
+	payload   json.RawMessage
+}
+
+func (s *fakeDeliveryStore) EnqueueWebhookDeliveries(_ context.Context, eventID int64, eventType string, payload json.RawMessage) (int64, error) {
+	s.eventID, s.eventType, s.payload = eventID, eventType, payload
+	return 1, nil
+}
+
+func TestFanout_EnqueuesEventEnvelope(t *testing.T) {
+	store := &fakeDeliveryStore{}
+	event := events.Event{ID: 9, Type: events.ItemDeleted, AggregateType: "item", AggregateID: 3, Data: json.RawMessage(`{"id":3}`)}
+
+	if err := NewFanout(store).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var got events.Event
+	if err := json.Unmarshal(store.payload, &got); err != nil {
+		t.Fatalf("payload is not an event: %v", err)
+	}
+	if store.eventID != 9 || store.eventType != events.ItemDeleted || got.ID != 9 || got.AggregateID != 3 {
+		t.Errorf("enqueued %d %s %s", store.eventID, store.eventType, store.payload)
+	}
+}